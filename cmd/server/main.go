@@ -12,9 +12,14 @@ import (
 	"github.com/corechain/notification-service/internal/delivery/kafka"
 	httpDelivery "github.com/corechain/notification-service/internal/delivery/http"
 	"github.com/corechain/notification-service/internal/delivery/http/handlers"
+	"github.com/corechain/notification-service/internal/delivery/worker"
+	"github.com/corechain/notification-service/internal/infrastructure/email"
 	"github.com/corechain/notification-service/internal/infrastructure/fcm"
 	kafkaInfra "github.com/corechain/notification-service/internal/infrastructure/kafka"
+	"github.com/corechain/notification-service/internal/infrastructure/realtime"
 	"github.com/corechain/notification-service/internal/infrastructure/repository/postgres"
+	"github.com/corechain/notification-service/internal/infrastructure/webhook"
+	"github.com/corechain/notification-service/internal/infrastructure/webpush"
 	"github.com/corechain/notification-service/internal/utils/logger"
 	"go.uber.org/zap"
 )
@@ -59,22 +64,183 @@ func main() {
 		zap.String("project_id", cfg.FCM.ProjectID),
 	)
 
-	notificationService := services.NewNotificationService(repository, fcmClient)
-	taskNotificationService := services.NewTaskNotificationService(notificationService)
+	attemptRepository := postgres.NewNotificationAttemptRepository(repository.DB())
+	deviceRepository := postgres.NewDeviceTokenRepository(repository.DB())
+
+	kafkaProducer := kafkaInfra.NewProducer(kafkaInfra.ProducerConfig{
+		Brokers: cfg.Kafka.Brokers,
+	})
+	defer kafkaProducer.Close()
+
+	topicSubscriptionRepository := postgres.NewTopicSubscriptionRepository(repository.DB())
+	topicSubscriptionService := services.NewTopicSubscriptionService(topicSubscriptionRepository, deviceRepository, fcmClient)
+	deviceTokenService := services.NewDeviceTokenService(deviceRepository, kafkaProducer, cfg.Kafka.Topics.DeviceTokenInvalidated, topicSubscriptionService)
+
+	channelRegistry := services.NewChannelRegistry()
+	channelRegistry.Register(services.NewPushChannel(fcmClient, deviceTokenService, repository))
+
+	if cfg.Email.Enabled {
+		emailRenderer, err := email.NewRenderer()
+		if err != nil {
+			logger.Fatal("Failed to load email templates", zap.Error(err))
+		}
+		smtpClient := email.NewClient(email.Config{
+			Host:        cfg.Email.SMTPHost,
+			Port:        cfg.Email.SMTPPort,
+			Username:    cfg.Email.Username,
+			Password:    cfg.Email.Password,
+			FromAddress: cfg.Email.FromAddress,
+			FromName:    cfg.Email.FromName,
+			TLSMode:     cfg.Email.TLSMode,
+			Timeout:     cfg.Email.Timeout(),
+		})
+		channelRegistry.Register(services.NewEmailChannel(smtpClient, emailRenderer))
+		logger.Info("Email channel enabled",
+			zap.String("smtp_host", cfg.Email.SMTPHost),
+			zap.Int("smtp_port", cfg.Email.SMTPPort),
+			zap.String("tls_mode", cfg.Email.TLSMode),
+		)
+	}
+
+	// Browser subscriptions are stored even while the channel is disabled
+	webPushRepository := postgres.NewWebPushSubscriptionRepository(repository.DB())
+	webPushService := services.NewWebPushService(webPushRepository, "", cfg.WebPush.AllowInsecureEndpoints)
+	if cfg.WebPush.Enabled {
+		webPushClient, err := webpush.NewClient(webpush.Config{
+			VAPIDPublicKey:  cfg.WebPush.VAPIDPublicKey,
+			VAPIDPrivateKey: cfg.WebPush.VAPIDPrivateKey,
+			Subject:         cfg.WebPush.Subject,
+			TTL:             cfg.WebPush.TTL(),
+			Timeout:         cfg.WebPush.Timeout(),
+		})
+		if err != nil {
+			logger.Fatal("Failed to initialize web push client", zap.Error(err))
+		}
+		webPushService = services.NewWebPushService(webPushRepository, webPushClient.PublicKey(), cfg.WebPush.AllowInsecureEndpoints)
+		channelRegistry.Register(services.NewWebPushChannel(webPushClient, webPushService))
+		logger.Info("Web push channel enabled", zap.String("subject", cfg.WebPush.Subject))
+	}
+
+	channelRouter, err := services.NewChannelRouter(channelRegistry, cfg.Channels.Default, cfg.Channels.Routes)
+	if err != nil {
+		logger.Fatal("Invalid notification channel routes", zap.Error(err))
+	}
+
+	preferenceRepository := postgres.NewNotificationPreferenceRepository(repository.DB())
+	preferenceService := services.NewPreferenceService(preferenceRepository, repository, channelRegistry)
+
+	notificationService := services.NewNotificationService(repository, attemptRepository, preferenceRepository, channelRouter, services.RetryPolicy{
+		MaxAttempts: cfg.Retry.MaxAttempts,
+		BaseDelay:   cfg.Retry.Delay(),
+		MaxDelay:    cfg.Retry.MaxDelay(),
+	})
+
+	if cfg.Coalesce.WindowSeconds > 0 {
+		notificationService.EnableCoalescing(cfg.Coalesce.Window())
+	}
+	notificationService.SetQuietHoursBypassPriority(cfg.QuietHours.BypassPriority)
+
+	// Digests can be read back even while collecting is disabled
+	digestRepository := postgres.NewNotificationDigestRepository(repository.DB())
+	digestService := services.NewDigestService(digestRepository, repository, notificationService, cfg.Digest.BatchSize)
+	if cfg.Digest.Enabled {
+		notificationService.EnableDigests(digestRepository, cfg.Digest.Window())
+	}
+
+	var reminderService *services.TaskReminderService
+	if cfg.Reminder.Enabled {
+		reminderRepository := postgres.NewTaskReminderRepository(repository.DB())
+		reminderService = services.NewTaskReminderService(reminderRepository, notificationService, cfg.Reminder.BatchSize)
+	}
+
+	taskNotificationService := services.NewTaskNotificationService(notificationService, reminderService)
+	messageNotificationService := services.NewMessageNotificationService(notificationService)
+	callNotificationService := services.NewCallNotificationService(notificationService)
+	broadcastService := services.NewBroadcastService(notificationService, topicSubscriptionService)
+
+	webhookService := services.NewWebhookService(
+		postgres.NewWebhookSubscriptionRepository(repository.DB()),
+		postgres.NewWebhookDeliveryRepository(repository.DB()),
+		webhook.NewClient(cfg.Webhook.Timeout()),
+		services.WebhookPolicy{
+			Retry: services.RetryPolicy{
+				MaxAttempts: cfg.Webhook.MaxAttempts,
+				BaseDelay:   cfg.Webhook.RetryDelay(),
+				MaxDelay:    cfg.Webhook.RetryMaxDelay(),
+			},
+			DisableAfter:   cfg.Webhook.DisableAfterFailures,
+			RequestTimeout: cfg.Webhook.Timeout(),
+		},
+	)
+	notificationService.AddSentListener(webhookService)
+
+	realtimeService := services.NewRealtimeService(
+		repository,
+		realtime.NewPGBroker(repository.DB(), cfg.Database.GetDSN(), cfg.Realtime.Channel),
+		cfg.Realtime.ReplayLimit,
+		cfg.Realtime.BufferSize,
+	)
+	notificationService.AddCreatedListener(realtimeService)
 
 	// Initialize HTTP server
 	logger.Info("Initializing HTTP server...")
 	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	deviceHandler := handlers.NewDeviceHandler(deviceTokenService)
+	broadcastHandler := handlers.NewBroadcastHandler(broadcastService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	streamHandler := handlers.NewStreamHandler(realtimeService, cfg.Realtime.Heartbeat())
+	webPushHandler := handlers.NewWebPushHandler(webPushService)
+	preferenceHandler := handlers.NewPreferenceHandler(preferenceService)
+	digestHandler := handlers.NewDigestHandler(digestService)
 	httpServer := httpDelivery.NewServer(httpDelivery.ServerConfig{
 		Port:                cfg.Server.Port,
 		NotificationHandler: notificationHandler,
+		DeviceHandler:       deviceHandler,
+		BroadcastHandler:    broadcastHandler,
+		WebhookHandler:      webhookHandler,
+		StreamHandler:       streamHandler,
+		WebPushHandler:      webPushHandler,
+		PreferenceHandler:   preferenceHandler,
+		DigestHandler:       digestHandler,
 	})
 
+	retryTiers := make([]kafkaInfra.RetryTier, 0, len(cfg.Kafka.RetryTiers))
+	for _, tier := range cfg.Kafka.RetryTiers {
+		retryTiers = append(retryTiers, kafkaInfra.RetryTier{Label: tier.Label, Delay: tier.Delay})
+	}
+
+	// Optional topics are only consumed when configured
+	topics := []string{cfg.Kafka.Topics.TaskCreated}
+	if cfg.Kafka.Topics.TaskUpdated != "" {
+		topics = append(topics, cfg.Kafka.Topics.TaskUpdated)
+	}
+	if cfg.Kafka.Topics.TaskEvents != "" {
+		topics = append(topics, cfg.Kafka.Topics.TaskEvents)
+	}
+	if cfg.Kafka.Topics.NewMessage != "" {
+		topics = append(topics, cfg.Kafka.Topics.NewMessage)
+	}
+	if cfg.Kafka.Topics.IncomingCall != "" {
+		topics = append(topics, cfg.Kafka.Topics.IncomingCall)
+	}
+	if cfg.Kafka.Topics.Membership != "" {
+		topics = append(topics, cfg.Kafka.Topics.Membership)
+	}
+
 	logger.Info("Initializing Kafka consumer...")
 	kafkaConsumer := kafkaInfra.NewConsumer(kafkaInfra.ConsumerConfig{
-		Brokers: cfg.Kafka.Brokers,
-		GroupID: cfg.Kafka.GroupID,
-		Topics:  []string{cfg.Kafka.Topics.TaskCreated},
+		Brokers:  cfg.Kafka.Brokers,
+		GroupID:  cfg.Kafka.GroupID,
+		Topics:   topics,
+		Producer: kafkaProducer,
+		DeadLetter: kafkaInfra.DeadLetterConfig{
+			Enabled:      cfg.Kafka.DeadLetter.Enabled,
+			TopicSuffix:  cfg.Kafka.DeadLetter.TopicSuffix,
+			Topics:       cfg.Kafka.DeadLetter.Topics,
+			MaxAttempts:  cfg.Kafka.DeadLetter.MaxAttempts,
+			RetryBackoff: cfg.Kafka.DeadLetter.RetryBackoff(),
+		},
+		RetryTiers: retryTiers,
 	})
 
 	taskHandler := kafka.NewTaskHandler(taskNotificationService)
@@ -82,8 +248,48 @@ func main() {
 		logger.Fatal("Failed to register task.created handler", zap.Error(err))
 	}
 
+	if cfg.Kafka.Topics.TaskUpdated != "" {
+		if err := kafkaConsumer.RegisterHandler(cfg.Kafka.Topics.TaskUpdated, taskHandler.HandleTaskUpdated); err != nil {
+			logger.Fatal("Failed to register task.updated handler", zap.Error(err))
+		}
+	}
+
+	if cfg.Kafka.Topics.TaskEvents != "" {
+		if err := kafkaConsumer.RegisterHandler(cfg.Kafka.Topics.TaskEvents, taskHandler.HandleTaskEvent); err != nil {
+			logger.Fatal("Failed to register task events handler", zap.Error(err))
+		}
+	}
+
+	if cfg.Kafka.Topics.NewMessage != "" {
+		messageHandler := kafka.NewMessageHandler(messageNotificationService)
+		if err := kafkaConsumer.RegisterHandler(cfg.Kafka.Topics.NewMessage, messageHandler.HandleNewMessage); err != nil {
+			logger.Fatal("Failed to register message.new handler", zap.Error(err))
+		}
+	}
+
+	if cfg.Kafka.Topics.IncomingCall != "" {
+		callHandler := kafka.NewCallHandler(callNotificationService)
+		if err := kafkaConsumer.RegisterHandler(cfg.Kafka.Topics.IncomingCall, callHandler.HandleIncomingCall); err != nil {
+			logger.Fatal("Failed to register call.incoming handler", zap.Error(err))
+		}
+	}
+
+	if cfg.Kafka.Topics.Membership != "" {
+		membershipHandler := kafka.NewMembershipHandler(topicSubscriptionService)
+		if err := kafkaConsumer.RegisterHandler(cfg.Kafka.Topics.Membership, membershipHandler.HandleMembershipEvent); err != nil {
+			logger.Fatal("Failed to register membership handler", zap.Error(err))
+		}
+	}
+
 	logger.Info("Registered Kafka handlers",
 		zap.String("task_created_topic", cfg.Kafka.Topics.TaskCreated),
+		zap.String("task_updated_topic", cfg.Kafka.Topics.TaskUpdated),
+		zap.String("task_events_topic", cfg.Kafka.Topics.TaskEvents),
+		zap.String("new_message_topic", cfg.Kafka.Topics.NewMessage),
+		zap.String("incoming_call_topic", cfg.Kafka.Topics.IncomingCall),
+		zap.String("membership_topic", cfg.Kafka.Topics.Membership),
+		zap.Bool("dead_letter_enabled", cfg.Kafka.DeadLetter.Enabled),
+		zap.Int("retry_tiers", len(retryTiers)),
 	)
 
 	// Start HTTP server in a goroutine
@@ -100,6 +306,37 @@ func main() {
 		logger.Fatal("Failed to start Kafka consumer", zap.Error(err))
 	}
 
+	schedulerWorker := worker.NewPeriodic("scheduled-notifications", cfg.Scheduler.PollInterval(), func(ctx context.Context) error {
+		return notificationService.ProcessScheduledNotifications(ctx, cfg.Scheduler.BatchSize)
+	})
+	schedulerWorker.Start(ctx)
+
+	retryWorker := worker.NewPeriodic("notification-retries", cfg.Retry.PollInterval(), func(ctx context.Context) error {
+		return notificationService.RetryFailedNotifications(ctx, cfg.Retry.BatchSize)
+	})
+	retryWorker.Start(ctx)
+
+	webhookWorker := worker.NewPeriodic("webhook-deliveries", cfg.Webhook.PollInterval(), func(ctx context.Context) error {
+		return webhookService.ProcessDueDeliveries(ctx, cfg.Webhook.BatchSize)
+	})
+	webhookWorker.Start(ctx)
+
+	// Receive the notifications announced by every replica
+	realtimeCtx, stopRealtime := context.WithCancel(ctx)
+	go realtimeService.Run(realtimeCtx)
+
+	var reminderWorker *worker.Periodic
+	if reminderService != nil {
+		reminderWorker = worker.NewPeriodic("task-reminders", cfg.Reminder.PollInterval(), reminderService.ProcessDueReminders)
+		reminderWorker.Start(ctx)
+	}
+
+	var digestWorker *worker.Periodic
+	if cfg.Digest.Enabled {
+		digestWorker = worker.NewPeriodic("notification-digests", cfg.Digest.PollInterval(), digestService.ProcessDueDigests)
+		digestWorker.Start(ctx)
+	}
+
 	logger.Info("Notification Service started successfully",
 		zap.Strings("kafka_brokers", cfg.Kafka.Brokers),
 		zap.String("consumer_group", cfg.Kafka.GroupID),
@@ -121,6 +358,21 @@ func main() {
 		logger.Error("Error stopping Kafka consumer", zap.Error(err))
 	}
 
+	// Stop background workers
+	schedulerWorker.Stop()
+	retryWorker.Stop()
+	webhookWorker.Stop()
+	if reminderWorker != nil {
+		reminderWorker.Stop()
+	}
+	if digestWorker != nil {
+		digestWorker.Stop()
+	}
+
+	// End open notification streams so the HTTP server can drain
+	realtimeService.Close()
+	stopRealtime()
+
 	// Stop HTTP server
 	if err := httpServer.Shutdown(shutdownCtx); err != nil {
 		logger.Error("Error stopping HTTP server", zap.Error(err))
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS task_reminders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id VARCHAR(100) NOT NULL,
+    user_id VARCHAR(100) NOT NULL,
+    fcm_token TEXT NOT NULL,
+    task_title VARCHAR(255) NOT NULL,
+    project_id VARCHAR(100),
+    priority INT,
+    due_date TIMESTAMP NOT NULL,
+    reminder_type VARCHAR(20) NOT NULL,
+    remind_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMP,
+    CONSTRAINT chk_reminder_type CHECK (reminder_type IN ('due_24h', 'due_1h', 'overdue')),
+    CONSTRAINT chk_reminder_status CHECK (status IN ('pending', 'sent', 'cancelled'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_reminders_due ON task_reminders(remind_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_task_reminders_task_id ON task_reminders(task_id);
@@ -0,0 +1,7 @@
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS send_at TIMESTAMP;
+
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS chk_status;
+ALTER TABLE notifications ADD CONSTRAINT chk_status
+    CHECK (status IN ('pending', 'sent', 'failed', 'scheduled', 'cancelled'));
+
+CREATE INDEX IF NOT EXISTS idx_notifications_scheduled ON notifications(send_at) WHERE status = 'scheduled';
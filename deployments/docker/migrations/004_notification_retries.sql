@@ -0,0 +1,7 @@
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS next_retry_at TIMESTAMP;
+
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS chk_status;
+ALTER TABLE notifications ADD CONSTRAINT chk_status
+    CHECK (status IN ('pending', 'sent', 'failed', 'scheduled', 'cancelled', 'permanently_failed'));
+
+CREATE INDEX IF NOT EXISTS idx_notifications_retry ON notifications(next_retry_at) WHERE status = 'failed';
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS notification_attempts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    notification_id UUID NOT NULL REFERENCES notifications(id) ON DELETE CASCADE,
+    attempt_number INT NOT NULL,
+    provider VARCHAR(20) NOT NULL,
+    provider_message_id TEXT,
+    status VARCHAR(20) NOT NULL,
+    error_code VARCHAR(50),
+    error_message TEXT,
+    latency_ms BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_attempt_status CHECK (status IN ('succeeded', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_attempts_notification_id ON notification_attempts(notification_id, attempt_number);
@@ -0,0 +1,25 @@
+-- user_fcm_tokens becomes a per-device registry: a user may own several devices
+ALTER TABLE user_fcm_tokens DROP CONSTRAINT IF EXISTS user_fcm_tokens_pkey;
+
+ALTER TABLE user_fcm_tokens ADD COLUMN IF NOT EXISTS id UUID NOT NULL DEFAULT uuid_generate_v4();
+ALTER TABLE user_fcm_tokens ADD COLUMN IF NOT EXISTS device_id VARCHAR(100);
+ALTER TABLE user_fcm_tokens ADD COLUMN IF NOT EXISTS app_version VARCHAR(50);
+ALTER TABLE user_fcm_tokens ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE user_fcm_tokens ADD COLUMN IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+UPDATE user_fcm_tokens SET device_id = 'legacy-' || user_id WHERE device_id IS NULL;
+ALTER TABLE user_fcm_tokens ALTER COLUMN device_id SET NOT NULL;
+
+ALTER TABLE user_fcm_tokens ADD PRIMARY KEY (id);
+
+-- A token belongs to one device. The old per-user table could hold the same
+-- token under several users when a device changed hands; only the most
+-- recently updated row is kept so the unique index below can be built.
+DELETE FROM user_fcm_tokens a
+USING user_fcm_tokens b
+WHERE a.fcm_token = b.fcm_token
+  AND (a.last_updated < b.last_updated OR (a.last_updated = b.last_updated AND a.id < b.id));
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_fcm_tokens_user_device ON user_fcm_tokens(user_id, device_id);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_fcm_tokens_token ON user_fcm_tokens(fcm_token);
+CREATE INDEX IF NOT EXISTS idx_fcm_tokens_user_active ON user_fcm_tokens(user_id, last_updated DESC) WHERE is_active;
@@ -0,0 +1,2 @@
+-- Per-device outcome of the latest delivery of a notification
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS deliveries JSONB;
@@ -0,0 +1,15 @@
+-- FCM topics each user's devices are subscribed to, e.g. project_<ProjectID>
+CREATE TABLE IF NOT EXISTS topic_subscriptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id VARCHAR(100) NOT NULL,
+    topic VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_topic_subscriptions_user_topic UNIQUE (user_id, topic)
+);
+
+CREATE INDEX IF NOT EXISTS idx_topic_subscriptions_topic ON topic_subscriptions(topic);
+
+-- A broadcast is one notification row addressed to a topic or condition
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS target_topic VARCHAR(255);
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS target_condition TEXT;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS recipient_count INT;
@@ -0,0 +1,2 @@
+-- Delivery state of a notification on each channel it was routed to
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS channels JSONB;
@@ -0,0 +1,4 @@
+-- Recipient address for the email channel, taken from the event that produced the notification
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS recipient_email VARCHAR(255);
+
+ALTER TABLE task_reminders ADD COLUMN IF NOT EXISTS email VARCHAR(255);
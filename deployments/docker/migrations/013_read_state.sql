@@ -0,0 +1,7 @@
+-- Inbox state: seen_at is set when the user opened the notification list,
+-- read_at when they opened the notification itself
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS read_at TIMESTAMP;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS seen_at TIMESTAMP;
+
+-- Unread counts run on every push to set the badge
+CREATE INDEX IF NOT EXISTS idx_notifications_user_unread ON notifications(user_id) WHERE read_at IS NULL;
@@ -0,0 +1,8 @@
+-- Keyset pagination of the notification list walks (created_at, id) per user;
+-- the filtered variants keep type and project filters on the index
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created ON notifications(user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_type_created ON notifications(user_id, notification_type, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_project_created ON notifications(user_id, project_id, created_at DESC, id DESC);
+
+-- Covered by the leading column of idx_notifications_user_created
+DROP INDEX IF EXISTS idx_notifications_user_id;
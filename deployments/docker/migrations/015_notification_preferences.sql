@@ -0,0 +1,28 @@
+-- Per-user notification preferences. channels replaces the routed channels
+-- when set; NULL keeps the service's routing.
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id VARCHAR(100) PRIMARY KEY,
+    channels JSONB,
+    muted BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Overrides for a notification type, a project or both; an empty string
+-- matches any. NULL channels or muted inherit the less specific setting.
+CREATE TABLE IF NOT EXISTS notification_preference_overrides (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id VARCHAR(100) NOT NULL REFERENCES notification_preferences(user_id) ON DELETE CASCADE,
+    notification_type VARCHAR(50) NOT NULL DEFAULT '',
+    project_id VARCHAR(100) NOT NULL DEFAULT '',
+    channels JSONB,
+    muted BOOLEAN,
+    CONSTRAINT chk_override_scope CHECK (notification_type <> '' OR project_id <> '')
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_notification_preference_overrides_scope
+    ON notification_preference_overrides(user_id, notification_type, project_id);
+
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS chk_status;
+ALTER TABLE notifications ADD CONSTRAINT chk_status
+    CHECK (status IN ('pending', 'sent', 'failed', 'scheduled', 'cancelled', 'permanently_failed', 'suppressed'));
@@ -0,0 +1,15 @@
+-- Quiet hours are a daily window of "HH:MM" clock times in the user's IANA
+-- time zone; do_not_disturb_until silences the user once
+ALTER TABLE notification_preferences ADD COLUMN IF NOT EXISTS quiet_hours_start VARCHAR(5) NOT NULL DEFAULT '';
+ALTER TABLE notification_preferences ADD COLUMN IF NOT EXISTS quiet_hours_end VARCHAR(5) NOT NULL DEFAULT '';
+ALTER TABLE notification_preferences ADD COLUMN IF NOT EXISTS time_zone VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE notification_preferences ADD COLUMN IF NOT EXISTS do_not_disturb_until TIMESTAMP;
+
+-- Deferred notifications wait in send_at for quiet hours to end and are
+-- released by the scheduler together with scheduled ones
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS chk_status;
+ALTER TABLE notifications ADD CONSTRAINT chk_status
+    CHECK (status IN ('pending', 'sent', 'failed', 'scheduled', 'cancelled', 'permanently_failed', 'suppressed', 'deferred'));
+
+DROP INDEX IF EXISTS idx_notifications_scheduled;
+CREATE INDEX IF NOT EXISTS idx_notifications_due ON notifications(send_at) WHERE status IN ('scheduled', 'deferred');
@@ -0,0 +1,30 @@
+-- Users who opt into the digest get their low-priority task notifications
+-- collected per project and summarised once the window closes
+ALTER TABLE notification_preferences ADD COLUMN IF NOT EXISTS digest BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS notification_digests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id VARCHAR(100) NOT NULL,
+    project_id VARCHAR(100) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    item_count INTEGER NOT NULL DEFAULT 0,
+    -- When the window closes; while sending, when the claim expires
+    send_at TIMESTAMP NOT NULL,
+    notification_id UUID REFERENCES notifications(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMP,
+    CONSTRAINT chk_digest_status CHECK (status IN ('open', 'sending', 'sent'))
+);
+
+-- One open digest per user and project collects new items
+CREATE UNIQUE INDEX IF NOT EXISTS uq_notification_digests_open
+    ON notification_digests(user_id, project_id) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_notification_digests_due
+    ON notification_digests(send_at) WHERE status IN ('open', 'sending');
+
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS digest_id UUID REFERENCES notification_digests(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_notifications_digest ON notifications(digest_id) WHERE digest_id IS NOT NULL;
+
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS chk_status;
+ALTER TABLE notifications ADD CONSTRAINT chk_status
+    CHECK (status IN ('pending', 'sent', 'failed', 'scheduled', 'cancelled', 'permanently_failed', 'suppressed', 'deferred', 'digested'));
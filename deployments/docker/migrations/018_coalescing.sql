@@ -0,0 +1,10 @@
+-- Repeated updates of a task wait as scheduled notifications under one
+-- coalesce key; a newer update supersedes the waiting one
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS coalesce_key VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_notifications_coalescing
+    ON notifications(coalesce_key) WHERE status = 'scheduled' AND coalesce_key <> '';
+
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS chk_status;
+ALTER TABLE notifications ADD CONSTRAINT chk_status
+    CHECK (status IN ('pending', 'sent', 'failed', 'scheduled', 'cancelled', 'permanently_failed', 'suppressed', 'deferred', 'digested', 'superseded'));
@@ -0,0 +1,7 @@
+-- Notifications remember the event they were created for, so an event
+-- delivered again by Kafka does not create them twice. One event may notify
+-- several users, hence the key is unique per user and type.
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS idempotency_key VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_notifications_idempotency
+    ON notifications(idempotency_key, user_id, notification_type) WHERE idempotency_key <> '';
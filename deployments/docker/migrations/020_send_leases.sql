@@ -0,0 +1,7 @@
+-- A notification stays pending while a replica sends it. claimed_until is
+-- the end of that replica's lease: once it has passed, the scheduler takes
+-- the notification over as if the replica had died mid-send. Rows left
+-- pending before this migration have no lease and are not taken over.
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_notifications_claimed_until ON notifications(claimed_until) WHERE status = 'pending';
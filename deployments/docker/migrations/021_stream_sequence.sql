@@ -0,0 +1,11 @@
+-- Realtime streams resume in the order notifications were announced, which
+-- for scheduled, deferred and coalesced ones is long after they were
+-- created. stream_seq is drawn from the sequence when a notification is
+-- announced and stays NULL until then, so notifications never pushed are not
+-- replayed either. Clients resuming from a notification announced before this
+-- migration get no replay.
+CREATE SEQUENCE IF NOT EXISTS notification_stream_seq;
+
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS stream_seq BIGINT;
+
+CREATE INDEX IF NOT EXISTS idx_notifications_stream ON notifications(user_id, stream_seq) WHERE stream_seq IS NOT NULL;
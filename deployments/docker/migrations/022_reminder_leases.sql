@@ -0,0 +1,5 @@
+-- A reminder stays pending until its notification has been created.
+-- claimed_until is the end of the lease of the replica sending it: once it
+-- has passed, the scheduler picks the reminder up again as if that replica
+-- had died mid-send.
+ALTER TABLE task_reminders ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMP;
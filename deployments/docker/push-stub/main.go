@@ -0,0 +1,361 @@
+// Command push-stub is a stand-in for a browser push service, for trying the
+// Web Push channel locally. It hands out subscriptions whose keys it holds,
+// checks the VAPID signature and encryption of every message pushed to them
+// and keeps the decrypted payloads for inspection.
+//
+//	GET  /subscriptions/new[?status=503]  a PushSubscription to register with the service
+//	POST /push/<id>                       the subscription endpoint
+//	GET  /messages                        decrypted messages, newest last
+//
+// Subscriptions live in memory; after a restart their endpoints answer 410
+// Gone, as a browser that dropped its subscription would.
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxMessages bounds how many decrypted messages are kept
+const maxMessages = 100
+
+type subscription struct {
+	key    *ecdh.PrivateKey
+	auth   []byte
+	status int
+}
+
+type message struct {
+	SubscriptionID string          `json:"subscription_id"`
+	ReceivedAt     time.Time       `json:"received_at"`
+	TTL            string          `json:"ttl"`
+	Urgency        string          `json:"urgency,omitempty"`
+	Payload        json.RawMessage `json:"payload"`
+}
+
+type stub struct {
+	baseURL string
+
+	mu            sync.Mutex
+	subscriptions map[string]*subscription
+	messages      []message
+}
+
+func main() {
+	baseURL := strings.TrimRight(env("PUSH_STUB_BASE_URL", "http://localhost:8080"), "/")
+	addr := env("PUSH_STUB_ADDR", ":8080")
+
+	s := &stub{baseURL: baseURL, subscriptions: make(map[string]*subscription)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/subscriptions/new", s.newSubscription)
+	mux.HandleFunc("/push/", s.push)
+	mux.HandleFunc("/messages", s.listMessages)
+
+	log.Printf("push stub listening on %s, endpoints under %s/push/", addr, baseURL)
+	log.Fatal(http.ListenAndServe(addr, mux))
+}
+
+// newSubscription creates a subscription and returns it in the format of
+// PushSubscription.toJSON(). The optional status is what its endpoint answers
+// once the message checks out, to try failure handling.
+func (s *stub) newSubscription(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	status := http.StatusCreated
+	if value := r.URL.Query().Get("status"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 200 || parsed > 599 {
+			http.Error(w, "status must be an HTTP status code", http.StatusBadRequest)
+			return
+		}
+		status = parsed
+	}
+
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	auth := make([]byte, 16)
+	id := make([]byte, 16)
+	if _, err := rand.Read(auth); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if _, err := rand.Read(id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	subscriptionID := hex.EncodeToString(id)
+	s.mu.Lock()
+	s.subscriptions[subscriptionID] = &subscription{key: key, auth: auth, status: status}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"endpoint": s.baseURL + "/push/" + subscriptionID,
+		"keys": map[string]string{
+			"p256dh": base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+			"auth":   base64.RawURLEncoding.EncodeToString(auth),
+		},
+	})
+}
+
+// push accepts a message the way a push service does: it must carry a TTL,
+// an aes128gcm body and a VAPID token signed for this origin
+func (s *stub) push(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	subscriptionID := strings.TrimPrefix(r.URL.Path, "/push/")
+	s.mu.Lock()
+	sub, ok := s.subscriptions[subscriptionID]
+	s.mu.Unlock()
+	if !ok {
+		http.Error(w, "unknown subscription", http.StatusGone)
+		return
+	}
+
+	if r.Header.Get("TTL") == "" {
+		http.Error(w, "missing TTL header", http.StatusBadRequest)
+		return
+	}
+	if r.Header.Get("Content-Encoding") != "aes128gcm" {
+		http.Error(w, "content encoding must be aes128gcm", http.StatusUnsupportedMediaType)
+		return
+	}
+	if err := s.verifyVAPID(r.Header.Get("Authorization")); err != nil {
+		http.Error(w, "VAPID: "+err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, 4097))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(body) > 4096 {
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	payload, err := decrypt(sub.key, sub.auth, body)
+	if err != nil {
+		http.Error(w, "decryption failed: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !json.Valid(payload) {
+		payload, _ = json.Marshal(string(payload))
+	}
+
+	log.Printf("push to %s (TTL %s, urgency %q, answering %d): %s",
+		subscriptionID, r.Header.Get("TTL"), r.Header.Get("Urgency"), sub.status, payload)
+
+	s.mu.Lock()
+	s.messages = append(s.messages, message{
+		SubscriptionID: subscriptionID,
+		ReceivedAt:     time.Now(),
+		TTL:            r.Header.Get("TTL"),
+		Urgency:        r.Header.Get("Urgency"),
+		Payload:        payload,
+	})
+	if len(s.messages) > maxMessages {
+		s.messages = s.messages[len(s.messages)-maxMessages:]
+	}
+	s.mu.Unlock()
+
+	if sub.status == http.StatusCreated {
+		w.Header().Set("Location", fmt.Sprintf("%s/messages?subscription=%s&n=%d", s.baseURL, subscriptionID, time.Now().UnixNano()))
+	}
+	w.WriteHeader(sub.status)
+}
+
+func (s *stub) listMessages(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	messages := make([]message, 0, len(s.messages))
+	for _, m := range s.messages {
+		if id := r.URL.Query().Get("subscription"); id == "" || id == m.SubscriptionID {
+			messages = append(messages, m)
+		}
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, messages)
+}
+
+// verifyVAPID checks an RFC 8292 "vapid t=<jwt>, k=<key>" header: the token
+// must be signed with k and addressed to this push service
+func (s *stub) verifyVAPID(header string) error {
+	params, ok := strings.CutPrefix(header, "vapid ")
+	if !ok {
+		return errors.New("authorization scheme must be vapid")
+	}
+
+	var token, key string
+	for _, param := range strings.Split(params, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		switch name {
+		case "t":
+			token = value
+		case "k":
+			key = value
+		}
+	}
+
+	rawKey, err := base64.RawURLEncoding.DecodeString(key)
+	if err != nil || len(rawKey) != 65 || rawKey[0] != 0x04 {
+		return errors.New("k is not an uncompressed P-256 key")
+	}
+	publicKey := &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(rawKey[1:33]),
+		Y:     new(big.Int).SetBytes(rawKey[33:65]),
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return errors.New("t is not a JWT")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || len(signature) != 64 {
+		return errors.New("signature is not an ES256 signature")
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r, sig := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+	if !ecdsa.Verify(publicKey, digest[:], r, sig) {
+		return errors.New("signature does not match k")
+	}
+
+	rawClaims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return errors.New("claims are not base64url")
+	}
+	var claims struct {
+		Aud string `json:"aud"`
+		Exp int64  `json:"exp"`
+		Sub string `json:"sub"`
+	}
+	if err := json.Unmarshal(rawClaims, &claims); err != nil {
+		return errors.New("claims are not JSON")
+	}
+
+	origin, err := url.Parse(s.baseURL)
+	if err != nil {
+		return err
+	}
+	if want := origin.Scheme + "://" + origin.Host; claims.Aud != want {
+		return fmt.Errorf("aud is %q, want %q", claims.Aud, want)
+	}
+	if expires := time.Unix(claims.Exp, 0); time.Now().After(expires) || time.Until(expires) > 24*time.Hour {
+		return fmt.Errorf("exp %s is not within the next 24 hours", expires.Format(time.RFC3339))
+	}
+	if !strings.HasPrefix(claims.Sub, "mailto:") && !strings.HasPrefix(claims.Sub, "https:") {
+		return fmt.Errorf("sub %q is not a mailto: or https: URL", claims.Sub)
+	}
+
+	return nil
+}
+
+// decrypt opens an aes128gcm body (RFC 8188) keyed as in RFC 8291 with the
+// subscription's private key
+func decrypt(key *ecdh.PrivateKey, auth []byte, body []byte) ([]byte, error) {
+	if len(body) < 21 {
+		return nil, errors.New("body is shorter than the header")
+	}
+	salt := body[:16]
+	idLength := int(body[20])
+	if len(body) < 21+idLength {
+		return nil, errors.New("body is shorter than the key ID")
+	}
+	if rs := binary.BigEndian.Uint32(body[16:20]); rs < 18 {
+		return nil, fmt.Errorf("record size %d is too small", rs)
+	}
+
+	serverPublic := body[21 : 21+idLength]
+	serverKey, err := ecdh.P256().NewPublicKey(serverPublic)
+	if err != nil {
+		return nil, fmt.Errorf("key ID is not a P-256 key: %w", err)
+	}
+	sharedSecret, err := key.ECDH(serverKey)
+	if err != nil {
+		return nil, err
+	}
+
+	keyInfo := append([]byte("WebPush: info\x00"), key.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, serverPublic...)
+	ikm := hkdf(sharedSecret, auth, keyInfo, 32)
+	contentKey := hkdf(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := hkdf(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	record, err := gcm.Open(nil, nonce, body[21+idLength:], nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The only record is the last one: zero padding follows the 0x02 delimiter
+	record = bytes.TrimRight(record, "\x00")
+	if len(record) == 0 || record[len(record)-1] != 0x02 {
+		return nil, errors.New("record has no last-record delimiter")
+	}
+	return record[:len(record)-1], nil
+}
+
+// hkdf derives size bytes, at most one SHA-256 block, as in RFC 5869
+func hkdf(secret []byte, salt []byte, info []byte, size int) []byte {
+	extract := hmac.New(sha256.New, salt)
+	extract.Write(secret)
+	expand := hmac.New(sha256.New, extract.Sum(nil))
+	expand.Write(info)
+	expand.Write([]byte{0x01})
+	return expand.Sum(nil)[:size]
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func env(name string, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
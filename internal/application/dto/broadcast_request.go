@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+// BroadcastRequest sends one notification to an FCM topic, such as
+// project_<ProjectID>, or to a condition like
+// "'project_a' in topics || 'project_b' in topics". Exactly one of Topic and
+// Condition must be set.
+type BroadcastRequest struct {
+	Topic     string            `json:"topic" binding:"omitempty,max=900"`
+	Condition string            `json:"condition" binding:"omitempty,max=1000"`
+	Title     string            `json:"title" binding:"required,max=255"`
+	Body      string            `json:"body" binding:"required"`
+	Data      map[string]string `json:"data"`
+	ProjectID string            `json:"project_id"`
+	SendAt    *time.Time        `json:"send_at"`
+}
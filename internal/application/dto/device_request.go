@@ -0,0 +1,15 @@
+package dto
+
+// RegisterDeviceRequest registers a device of a user or replaces its token
+type RegisterDeviceRequest struct {
+	DeviceID   string `json:"device_id" binding:"required,max=100"`
+	FCMToken   string `json:"fcm_token" binding:"required"`
+	Platform   string `json:"platform" binding:"required,oneof=android ios web"`
+	AppVersion string `json:"app_version" binding:"max=50"`
+}
+
+// RefreshDeviceRequest replaces the token of an already registered device
+type RefreshDeviceRequest struct {
+	FCMToken   string `json:"fcm_token" binding:"required"`
+	AppVersion string `json:"app_version" binding:"max=50"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+// DeviceTokenInvalidatedEvent tells the backend that FCM rejected a device
+// token for good, so it can stop storing and sending it
+type DeviceTokenInvalidatedEvent struct {
+	EventType      string    `json:"event_type"`
+	Timestamp      time.Time `json:"timestamp"`
+	UserID         string    `json:"user_id"`
+	DeviceID       string    `json:"device_id,omitempty"`
+	Platform       string    `json:"platform,omitempty"`
+	FCMToken       string    `json:"fcm_token"`
+	Reason         string    `json:"reason"`
+	NotificationID string    `json:"notification_id,omitempty"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/corechain/notification-service/pkg/constants"
+)
+
+// MembershipEvent covers project.member_added, project.member_removed,
+// company.member_added and company.member_removed events
+type MembershipEvent struct {
+	EventType string         `json:"event_type"`
+	Timestamp time.Time      `json:"timestamp"`
+	Data      MembershipData `json:"data"`
+}
+
+type MembershipData struct {
+	UserID    string `json:"userId"`
+	ProjectID string `json:"projectId,omitempty"`
+	CompanyID string `json:"companyId,omitempty"`
+}
+
+// Topic returns the FCM topic the membership maps to, or an empty string for
+// unknown event types
+func (e *MembershipEvent) Topic() string {
+	switch e.EventType {
+	case constants.EventTypeProjectMemberAdded, constants.EventTypeProjectMemberRemoved:
+		return constants.TopicPrefixProject + e.Data.ProjectID
+	case constants.EventTypeCompanyMemberAdded, constants.EventTypeCompanyMemberRemoved:
+		return constants.TopicPrefixCompany + e.Data.CompanyID
+	default:
+		return ""
+	}
+}
+
+// IsRemoval reports whether the user left the project or company
+func (e *MembershipEvent) IsRemoval() bool {
+	return e.EventType == constants.EventTypeProjectMemberRemoved || e.EventType == constants.EventTypeCompanyMemberRemoved
+}
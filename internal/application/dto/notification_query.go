@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+// ListNotificationsQuery holds the filters of the notification list. Status
+// accepts several comma-separated values.
+type ListNotificationsQuery struct {
+	Cursor           string     `form:"cursor"`
+	NotificationType string     `form:"notification_type"`
+	Status           string     `form:"status"`
+	ProjectID        string     `form:"project_id"`
+	TaskID           string     `form:"task_id"`
+	Read             *bool      `form:"read"`
+	CreatedFrom      *time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo        *time.Time `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Order            string     `form:"order" binding:"omitempty,oneof=asc desc"`
+	IncludeTotal     bool       `form:"include_total"`
+}
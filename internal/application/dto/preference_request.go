@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// UpdatePreferencesRequest replaces every preference of a user. Channels lists
+// the channels notifications go out on instead of the routed ones; leaving it
+// out keeps the routing, an empty list turns every channel off.
+type UpdatePreferencesRequest struct {
+	Channels  []string                    `json:"channels" binding:"omitempty,max=10,dive,required,max=50"`
+	Muted     bool                        `json:"muted"`
+	Overrides []PreferenceOverrideRequest `json:"overrides" binding:"max=200,dive"`
+	// Digest collects low-priority task notifications into periodic summaries
+	Digest bool `json:"digest"`
+	// QuietHours turns the daily quiet window on; leaving it out turns it off
+	QuietHours *QuietHoursRequest `json:"quiet_hours"`
+}
+
+// QuietHoursRequest is a daily window of "15:04" clock times in an IANA time
+// zone such as "Europe/Berlin"; it may run past midnight
+type QuietHoursRequest struct {
+	Start    string `json:"start" binding:"required,len=5"`
+	End      string `json:"end" binding:"required,len=5"`
+	TimeZone string `json:"time_zone" binding:"required,max=64"`
+}
+
+// DoNotDisturbRequest holds back notifications that aren't urgent until the
+// given time
+type DoNotDisturbRequest struct {
+	Until time.Time `json:"until" binding:"required"`
+}
+
+// PreferenceOverrideRequest changes the preferences for a notification type,
+// a project or a type within a project. Fields left out inherit the less
+// specific setting.
+type PreferenceOverrideRequest struct {
+	NotificationType string   `json:"notification_type" binding:"max=50"`
+	ProjectID        string   `json:"project_id" binding:"max=100"`
+	Channels         []string `json:"channels" binding:"omitempty,max=10,dive,required,max=50"`
+	Muted            *bool    `json:"muted"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+import (
+	"github.com/corechain/notification-service/internal/domain/models"
+)
+
+// RealtimeMessage is the frame sent to WebSocket clients
+type RealtimeMessage struct {
+	ID    string               `json:"id"`
+	Event string               `json:"event"`
+	Data  *models.Notification `json:"data"`
+}
@@ -11,6 +11,8 @@ type TaskCreatedEvent struct {
 	Timestamp time.Time              `json:"timestamp"`
 	Data      models.Task            `json:"data"`
 	Metadata  TaskEventMetadata      `json:"metadata"`
+	// SendAt optionally delays the notification until the given time
+	SendAt    *time.Time             `json:"send_at,omitempty"`
 }
 
 type TaskEventMetadata struct {
@@ -25,15 +27,45 @@ type AssignedUserInfo struct {
 }
 
 type MessageCreatedEvent struct {
-	EventType string         `json:"event_type"`
-	Timestamp time.Time      `json:"timestamp"`
-	Data      models.Message `json:"data"`
-	Metadata  interface{}    `json:"metadata"`
+	EventType string               `json:"event_type"`
+	Timestamp time.Time            `json:"timestamp"`
+	Data      models.Message       `json:"data"`
+	Metadata  MessageEventMetadata `json:"metadata"`
+	SendAt    *time.Time           `json:"send_at,omitempty"`
+}
+
+type MessageEventMetadata struct {
+	SenderUser    models.UserInfo  `json:"senderUser"`
+	RecipientUser AssignedUserInfo `json:"recipientUser"`
 }
 
 type IncomingCallEvent struct {
-	EventType string       `json:"event_type"`
-	Timestamp time.Time    `json:"timestamp"`
-	Data      models.Call  `json:"data"`
-	Metadata  interface{}  `json:"metadata"`
+	EventType string            `json:"event_type"`
+	Timestamp time.Time         `json:"timestamp"`
+	Data      models.Call       `json:"data"`
+	Metadata  CallEventMetadata `json:"metadata"`
+	SendAt    *time.Time        `json:"send_at,omitempty"`
+}
+
+type CallEventMetadata struct {
+	CallerUser    models.UserInfo  `json:"callerUser"`
+	RecipientUser AssignedUserInfo `json:"recipientUser"`
+}
+
+// RecipientID returns the user the message is addressed to, preferring the
+// resolved recipient in the metadata over the raw receiver reference
+func (e *MessageCreatedEvent) RecipientID() string {
+	if e.Metadata.RecipientUser.ID != "" {
+		return e.Metadata.RecipientUser.ID
+	}
+	return e.Data.ReceiverID
+}
+
+// RecipientID returns the user being called, preferring the resolved
+// recipient in the metadata over the raw receiver reference
+func (e *IncomingCallEvent) RecipientID() string {
+	if e.Metadata.RecipientUser.ID != "" {
+		return e.Metadata.RecipientUser.ID
+	}
+	return e.Data.ReceiverID
 }
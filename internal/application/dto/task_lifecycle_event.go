@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/corechain/notification-service/internal/domain/models"
+)
+
+// TaskEventEnvelope is decoded first to route an event on the shared task
+// topic by its event_type
+type TaskEventEnvelope struct {
+	EventType string `json:"event_type"`
+}
+
+// TaskLifecycleEvent covers task.deleted, task.reassigned, task.status_changed
+// and task.completed events
+type TaskLifecycleEvent struct {
+	EventType string                 `json:"event_type"`
+	Timestamp time.Time              `json:"timestamp"`
+	Data      models.Task            `json:"data"`
+	Changes   map[string]FieldChange `json:"changes,omitempty"`
+	Metadata  TaskLifecycleMetadata  `json:"metadata"`
+	SendAt    *time.Time             `json:"send_at,omitempty"`
+}
+
+type TaskLifecycleMetadata struct {
+	AssignedToUser         AssignedUserInfo `json:"assignedToUser"`
+	PreviousAssignedToUser AssignedUserInfo `json:"previousAssignedToUser"`
+	CreatedByUser          AssignedUserInfo `json:"createdByUser"`
+	PreviousStatus         *int             `json:"previousStatus,omitempty"`
+}
+
+// Actor returns the user who triggered the event, if known
+func (e *TaskLifecycleEvent) Actor() *models.UserInfo {
+	if e.Data.DeletedBy != nil {
+		return e.Data.DeletedBy
+	}
+	return e.Data.UpdatedBy
+}
+
+// ActorID returns the ID of the user who triggered the event, if known
+func (e *TaskLifecycleEvent) ActorID() string {
+	if actor := e.Actor(); actor != nil {
+		return actor.ID
+	}
+	return ""
+}
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"sort"
+	"time"
+
+	"github.com/corechain/notification-service/internal/domain/models"
+)
+
+type TaskUpdatedEvent struct {
+	EventType string                 `json:"event_type"`
+	Timestamp time.Time              `json:"timestamp"`
+	Data      models.Task            `json:"data"`
+	Changes   map[string]FieldChange `json:"changes"`
+	Metadata  TaskEventMetadata      `json:"metadata"`
+	SendAt    *time.Time             `json:"send_at,omitempty"`
+}
+
+// FieldChange holds the previous and new value of a modified task field
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// ChangedFields returns the names of the modified fields in a stable order
+func (e *TaskUpdatedEvent) ChangedFields() []string {
+	fields := make([]string, 0, len(e.Changes))
+	for field := range e.Changes {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// UpdatedByID returns the ID of the user who made the change, if known
+func (e *TaskUpdatedEvent) UpdatedByID() string {
+	if e.Data.UpdatedBy == nil {
+		return ""
+	}
+	return e.Data.UpdatedBy.ID
+}
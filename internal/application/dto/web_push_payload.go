@@ -0,0 +1,11 @@
+package dto
+
+// WebPushPayload is the JSON the service worker receives in its push event
+type WebPushPayload struct {
+	NotificationID string                 `json:"notification_id"`
+	Type           string                 `json:"type"`
+	Title          string                 `json:"title"`
+	Body           string                 `json:"body"`
+	Data           map[string]interface{} `json:"data,omitempty"`
+	Priority       int                    `json:"priority,omitempty"`
+}
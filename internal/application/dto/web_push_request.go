@@ -0,0 +1,15 @@
+package dto
+
+// RegisterWebPushSubscriptionRequest is the JSON of a browser PushSubscription
+// as returned by subscription.toJSON(), plus an optional user agent label
+type RegisterWebPushSubscriptionRequest struct {
+	Endpoint  string      `json:"endpoint" binding:"required,url"`
+	Keys      WebPushKeys `json:"keys" binding:"required"`
+	UserAgent string      `json:"user_agent" binding:"max=255"`
+}
+
+// WebPushKeys are the base64url encoded keys the payload is encrypted for
+type WebPushKeys struct {
+	P256dh string `json:"p256dh" binding:"required"`
+	Auth   string `json:"auth" binding:"required"`
+}
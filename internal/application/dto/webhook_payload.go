@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/pkg/constants"
+)
+
+// WebhookPayload is the JSON body posted to webhook endpoints
+type WebhookPayload struct {
+	Event        string              `json:"event"`
+	SentAt       time.Time           `json:"sent_at"`
+	Notification WebhookNotification `json:"notification"`
+}
+
+// WebhookNotification is the part of a notification webhook endpoints may
+// see. Fields are listed one by one so recipient contact details, device
+// tokens and fields added to models.Notification later never reach a third
+// party by accident.
+type WebhookNotification struct {
+	ID               string                       `json:"id"`
+	NotificationType constants.NotificationType   `json:"notification_type"`
+	UserID           string                       `json:"user_id"`
+	Title            string                       `json:"title"`
+	Body             string                       `json:"body"`
+	Data             map[string]interface{}       `json:"data,omitempty"`
+	Status           constants.NotificationStatus `json:"status"`
+	CreatedAt        time.Time                    `json:"created_at"`
+	SentAt           *time.Time                   `json:"sent_at,omitempty"`
+	TaskID           string                       `json:"task_id,omitempty"`
+	ProjectID        string                       `json:"project_id,omitempty"`
+	Priority         int                          `json:"priority,omitempty"`
+	Channels         []models.ChannelDelivery     `json:"channels,omitempty"`
+}
+
+// NewWebhookNotification copies the allow-listed fields of a notification
+func NewWebhookNotification(notification *models.Notification) WebhookNotification {
+	return WebhookNotification{
+		ID:               notification.ID,
+		NotificationType: notification.NotificationType,
+		UserID:           notification.UserID,
+		Title:            notification.Title,
+		Body:             notification.Body,
+		Data:             notification.Data,
+		Status:           notification.Status,
+		CreatedAt:        notification.CreatedAt,
+		SentAt:           notification.SentAt,
+		TaskID:           notification.TaskID,
+		ProjectID:        notification.ProjectID,
+		Priority:         notification.Priority,
+		Channels:         notification.Channels,
+	}
+}
@@ -0,0 +1,23 @@
+package dto
+
+// CreateWebhookRequest registers a webhook endpoint. ProjectID and
+// NotificationType narrow which sent notifications it receives; a secret is
+// generated when none is given.
+type CreateWebhookRequest struct {
+	URL              string `json:"url" binding:"required,url"`
+	Secret           string `json:"secret" binding:"omitempty,min=16,max=255"`
+	Description      string `json:"description" binding:"max=255"`
+	ProjectID        string `json:"project_id" binding:"max=100"`
+	NotificationType string `json:"notification_type" binding:"max=50"`
+}
+
+// UpdateWebhookRequest changes the fields that are set. Setting is_active to
+// true re-enables a subscription that was disabled after repeated failures.
+type UpdateWebhookRequest struct {
+	URL              *string `json:"url" binding:"omitempty,url"`
+	Secret           *string `json:"secret" binding:"omitempty,min=16,max=255"`
+	Description      *string `json:"description" binding:"omitempty,max=255"`
+	ProjectID        *string `json:"project_id" binding:"omitempty,max=100"`
+	NotificationType *string `json:"notification_type" binding:"omitempty,max=50"`
+	IsActive         *bool   `json:"is_active"`
+}
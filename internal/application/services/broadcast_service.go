@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+
+	"github.com/corechain/notification-service/internal/application/dto"
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"github.com/corechain/notification-service/pkg/constants"
+	"go.uber.org/zap"
+)
+
+// BroadcastService sends announcements to FCM topics and conditions. Each
+// broadcast is stored as a single notification row with its recipient count.
+type BroadcastService struct {
+	notificationService *NotificationService
+	subscriptions       *TopicSubscriptionService
+}
+
+func NewBroadcastService(notificationService *NotificationService, subscriptions *TopicSubscriptionService) *BroadcastService {
+	return &BroadcastService{
+		notificationService: notificationService,
+		subscriptions:       subscriptions,
+	}
+}
+
+// Broadcast stores and sends a broadcast. A request repeated with the same
+// idempotency key returns the broadcast stored the first time instead of
+// sending it again. Once the broadcast is stored it is returned with its
+// status even if sending failed, since it is already recorded and retried
+// like any other notification.
+func (s *BroadcastService) Broadcast(ctx context.Context, req *dto.BroadcastRequest, idempotencyKey string) (*models.Notification, error) {
+	if (req.Topic == "") == (req.Condition == "") {
+		return nil, errors.NewInvalidPayloadError("exactly one of topic and condition is required", nil)
+	}
+
+	recipients, err := s.subscriptions.CountRecipients(ctx, req.Topic, req.Condition)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]interface{}, len(req.Data)+1)
+	for k, v := range req.Data {
+		data[k] = v
+	}
+	data["type"] = string(constants.NotificationTypeBroadcast)
+
+	notification := &models.Notification{
+		NotificationType: constants.NotificationTypeBroadcast,
+		Title:            req.Title,
+		Body:             req.Body,
+		Data:             data,
+		ProjectID:        req.ProjectID,
+		SendAt:           req.SendAt,
+		TargetTopic:      req.Topic,
+		TargetCondition:  req.Condition,
+		RecipientCount:   recipients,
+	}
+	if idempotencyKey != "" {
+		notification.IdempotencyKey = "broadcast:" + idempotencyKey
+	}
+
+	if err := s.notificationService.CreateAndSendNotification(ctx, notification); err != nil {
+		if notification.ID == "" {
+			return nil, err
+		}
+		logger.Warn("Stored broadcast could not be sent",
+			zap.Error(err),
+			zap.String("notification_id", notification.ID),
+		)
+	}
+
+	// Reload to report the delivery status
+	stored, err := s.notificationService.GetNotificationByID(ctx, notification.ID)
+	if err != nil {
+		logger.Warn("Failed to reload broadcast, reporting its last known status",
+			zap.Error(err),
+			zap.String("notification_id", notification.ID),
+		)
+		return notification, nil
+	}
+
+	return stored, nil
+}
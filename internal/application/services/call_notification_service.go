@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+
+	"github.com/corechain/notification-service/internal/application/dto"
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/infrastructure/fcm"
+	"github.com/corechain/notification-service/pkg/constants"
+)
+
+type CallNotificationService struct {
+	notificationService *NotificationService
+}
+
+func NewCallNotificationService(notificationService *NotificationService) *CallNotificationService {
+	return &CallNotificationService{
+		notificationService: notificationService,
+	}
+}
+
+func (s *CallNotificationService) ProcessIncomingCallEvent(ctx context.Context, event *dto.IncomingCallEvent) error {
+	template := fcm.BuildIncomingCallNotification(
+		displayName(event.Metadata.CallerUser),
+		event.Data.CallType,
+	)
+
+	data := map[string]interface{}{
+		"type":         "incoming_call",
+		"call_id":      event.Data.ID,
+		"caller_id":    event.Data.CallerID,
+		"call_type":    event.Data.CallType,
+		"click_action": "OPEN_INCOMING_CALL",
+	}
+
+	notification := &models.Notification{
+		NotificationType: constants.NotificationTypeIncomingCall,
+		UserID:           event.RecipientID(),
+		FCMToken:         event.Metadata.RecipientUser.FCMToken,
+		RecipientEmail:   event.Metadata.RecipientUser.Email,
+		Title:            template.Title,
+		Body:             template.Body,
+		Data:             data,
+		Priority:         constants.PriorityHigh,
+		SendAt:           event.SendAt,
+	}
+
+	return s.notificationService.CreateAndSendNotification(ctx, notification)
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/corechain/notification-service/internal/domain/interfaces"
+	"github.com/corechain/notification-service/internal/domain/models"
+)
+
+// ChannelRegistry holds the delivery channels available to the service
+type ChannelRegistry struct {
+	channels map[string]interfaces.Channel
+	order    []string
+}
+
+func NewChannelRegistry() *ChannelRegistry {
+	return &ChannelRegistry{
+		channels: make(map[string]interfaces.Channel),
+	}
+}
+
+// Register adds a channel; registering a name twice replaces the channel
+func (r *ChannelRegistry) Register(channel interfaces.Channel) {
+	if _, exists := r.channels[channel.Name()]; !exists {
+		r.order = append(r.order, channel.Name())
+	}
+	r.channels[channel.Name()] = channel
+}
+
+func (r *ChannelRegistry) Get(name string) (interfaces.Channel, bool) {
+	channel, ok := r.channels[name]
+	return channel, ok
+}
+
+// Names returns the registered channel names in registration order
+func (r *ChannelRegistry) Names() []string {
+	return append([]string(nil), r.order...)
+}
+
+// ChannelRouter decides which channels a notification goes out on. Direct
+// notifications follow the routes of their type, falling back to the default
+// channels; broadcasts go to every channel that can deliver them.
+type ChannelRouter struct {
+	registry *ChannelRegistry
+	defaults []string
+	routes   map[string][]string
+}
+
+// NewChannelRouter creates a router and checks that every channel it refers
+// to is registered
+func NewChannelRouter(registry *ChannelRegistry, defaults []string, routes map[string][]string) (*ChannelRouter, error) {
+	router := &ChannelRouter{
+		registry: registry,
+		defaults: defaults,
+		routes:   routes,
+	}
+
+	check := func(names []string) error {
+		for _, name := range names {
+			if _, ok := registry.Get(name); !ok {
+				return fmt.Errorf("unknown notification channel: %s", name)
+			}
+		}
+		return nil
+	}
+
+	if err := check(defaults); err != nil {
+		return nil, err
+	}
+	for _, names := range routes {
+		if err := check(names); err != nil {
+			return nil, err
+		}
+	}
+
+	return router, nil
+}
+
+// Route returns the channels a notification should be delivered on
+func (r *ChannelRouter) Route(notification *models.Notification) []interfaces.Channel {
+	if notification.IsBroadcast() {
+		var channels []interfaces.Channel
+		for _, name := range r.registry.Names() {
+			channel, _ := r.registry.Get(name)
+			if channel.Capabilities().Broadcast {
+				channels = append(channels, channel)
+			}
+		}
+		return channels
+	}
+
+	names, ok := r.routes[string(notification.NotificationType)]
+	if !ok {
+		names = r.defaults
+	}
+
+	return r.Select(names)
+}
+
+// Select returns the named channels that deliver direct notifications,
+// skipping names that are not registered
+func (r *ChannelRouter) Select(names []string) []interfaces.Channel {
+	channels := make([]interfaces.Channel, 0, len(names))
+	for _, name := range names {
+		channel, ok := r.registry.Get(name)
+		if ok && channel.Capabilities().Direct {
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/corechain/notification-service/internal/application/dto"
+	"github.com/corechain/notification-service/internal/domain/interfaces"
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"github.com/corechain/notification-service/pkg/constants"
+	"go.uber.org/zap"
+)
+
+// DeviceTokenService manages the FCM tokens registered for each user's devices
+type DeviceTokenService struct {
+	repository       interfaces.DeviceTokenRepository
+	publisher        interfaces.EventPublisher
+	invalidatedTopic string
+	subscriptions    *TopicSubscriptionService
+}
+
+// NewDeviceTokenService creates the service. Invalidated tokens are announced
+// on invalidatedTopic; an empty topic or nil publisher disables the event.
+// Registered devices join the FCM topics of their user through subscriptions.
+func NewDeviceTokenService(repo interfaces.DeviceTokenRepository, publisher interfaces.EventPublisher, invalidatedTopic string, subscriptions *TopicSubscriptionService) *DeviceTokenService {
+	return &DeviceTokenService{
+		repository:       repo,
+		publisher:        publisher,
+		invalidatedTopic: invalidatedTopic,
+		subscriptions:    subscriptions,
+	}
+}
+
+func (s *DeviceTokenService) RegisterDevice(ctx context.Context, userID string, req *dto.RegisterDeviceRequest) (*models.DeviceToken, error) {
+	device := &models.DeviceToken{
+		UserID:     userID,
+		DeviceID:   req.DeviceID,
+		FCMToken:   req.FCMToken,
+		Platform:   req.Platform,
+		AppVersion: req.AppVersion,
+	}
+
+	if err := s.repository.Upsert(ctx, device); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Registered device",
+		zap.String("user_id", userID),
+		zap.String("device_id", device.DeviceID),
+		zap.String("platform", device.Platform),
+	)
+
+	s.syncTopics(ctx, device, true)
+
+	return device, nil
+}
+
+func (s *DeviceTokenService) RefreshDevice(ctx context.Context, userID string, deviceID string, req *dto.RefreshDeviceRequest) (*models.DeviceToken, error) {
+	device, err := s.repository.Refresh(ctx, userID, deviceID, req.FCMToken, req.AppVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Refreshed device token",
+		zap.String("user_id", userID),
+		zap.String("device_id", deviceID),
+	)
+
+	s.syncTopics(ctx, device, true)
+
+	return device, nil
+}
+
+func (s *DeviceTokenService) UnregisterDevice(ctx context.Context, userID string, deviceID string) error {
+	device, err := s.repository.Delete(ctx, userID, deviceID)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Unregistered device",
+		zap.String("user_id", userID),
+		zap.String("device_id", deviceID),
+	)
+
+	s.syncTopics(ctx, device, false)
+
+	return nil
+}
+
+// syncTopics subscribes a device to, or removes it from, the FCM topics of its
+// user. Failures are logged; the registry change itself already succeeded.
+func (s *DeviceTokenService) syncTopics(ctx context.Context, device *models.DeviceToken, subscribe bool) {
+	if s.subscriptions == nil {
+		return
+	}
+
+	var err error
+	if subscribe {
+		err = s.subscriptions.SubscribeDevice(ctx, device)
+	} else {
+		err = s.subscriptions.UnsubscribeDevice(ctx, device)
+	}
+
+	if err != nil {
+		logger.Error("Failed to sync device topic subscriptions",
+			zap.Error(err),
+			zap.String("user_id", device.UserID),
+			zap.String("device_id", device.DeviceID),
+			zap.Bool("subscribe", subscribe),
+		)
+	}
+}
+
+func (s *DeviceTokenService) GetUserDevices(ctx context.Context, userID string) ([]*models.DeviceToken, error) {
+	return s.repository.GetByUserID(ctx, userID)
+}
+
+// GetActiveDevices returns the devices a notification for the user should be
+// pushed to, most recently updated first
+func (s *DeviceTokenService) GetActiveDevices(ctx context.Context, userID string) ([]*models.DeviceToken, error) {
+	return s.repository.GetActiveByUserID(ctx, userID)
+}
+
+// InvalidateToken deactivates a token FCM rejected for good and emits a
+// device.token.invalidated event. Tokens unknown to the registry, such as
+// ones carried by Kafka events, are still announced so the backend drops them.
+func (s *DeviceTokenService) InvalidateToken(ctx context.Context, userID string, fcmToken string, reason string, notificationID string) error {
+	device, err := s.repository.DeactivateToken(ctx, fcmToken)
+	if err != nil {
+		return err
+	}
+
+	event := &dto.DeviceTokenInvalidatedEvent{
+		EventType:      constants.EventTypeDeviceTokenInvalidated,
+		Timestamp:      time.Now().UTC(),
+		UserID:         userID,
+		FCMToken:       fcmToken,
+		Reason:         reason,
+		NotificationID: notificationID,
+	}
+	if device != nil {
+		event.UserID = device.UserID
+		event.DeviceID = device.DeviceID
+		event.Platform = device.Platform
+	}
+
+	logger.Warn("Invalidated device token",
+		zap.String("user_id", event.UserID),
+		zap.String("device_id", event.DeviceID),
+		zap.String("reason", reason),
+	)
+
+	if s.publisher == nil || s.invalidatedTopic == "" {
+		return nil
+	}
+
+	return s.publisher.PublishJSON(ctx, s.invalidatedTopic, event.UserID, event)
+}
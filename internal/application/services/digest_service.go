@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/corechain/notification-service/internal/domain/interfaces"
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/infrastructure/fcm"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"github.com/corechain/notification-service/pkg/constants"
+	"go.uber.org/zap"
+)
+
+// digestLease is how long a claimed digest is left to one replica before
+// another may send it
+const digestLease = 5 * time.Minute
+
+// DigestService sends one summary notification for each digest whose window
+// has closed
+type DigestService struct {
+	repository          interfaces.NotificationDigestRepository
+	notifications       interfaces.NotificationRepository
+	notificationService *NotificationService
+	batchSize           int
+}
+
+func NewDigestService(repo interfaces.NotificationDigestRepository, notificationRepo interfaces.NotificationRepository, notificationService *NotificationService, batchSize int) *DigestService {
+	return &DigestService{
+		repository:          repo,
+		notifications:       notificationRepo,
+		notificationService: notificationService,
+		batchSize:           batchSize,
+	}
+}
+
+// GetDigest returns a digest together with the notifications it collected
+func (s *DigestService) GetDigest(ctx context.Context, id string) (*models.NotificationDigest, error) {
+	digest, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.notifications.GetByDigestID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	digest.Items = items
+
+	return digest, nil
+}
+
+// ProcessDueDigests claims the digests whose window has closed and sends
+// their summaries. Claiming is safe across replicas; a digest whose summary
+// could not be created is tried again once its lease expires.
+func (s *DigestService) ProcessDueDigests(ctx context.Context) error {
+	digests, err := s.repository.ClaimDue(ctx, time.Now(), digestLease, s.batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, digest := range digests {
+		if err := s.send(ctx, digest); err != nil {
+			logger.Error("Failed to send notification digest",
+				zap.Error(err),
+				zap.String("digest_id", digest.ID),
+				zap.String("user_id", digest.UserID),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (s *DigestService) send(ctx context.Context, digest *models.NotificationDigest) error {
+	items, err := s.notifications.GetByDigestID(ctx, digest.ID)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		// Every item was deleted meanwhile; there is nothing to announce
+		return s.repository.MarkSent(ctx, digest.ID, "")
+	}
+
+	created, updated := 0, 0
+	projectName := ""
+	var titles []string
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		if item.NotificationType == constants.NotificationTypeTaskCreated {
+			created++
+		} else {
+			updated++
+		}
+		if projectName == "" {
+			projectName = dataString(item.Data, "project_name")
+		}
+
+		// Items are newest first, so each task is listed under its latest title
+		if seen[item.TaskID] {
+			continue
+		}
+		seen[item.TaskID] = true
+		if title := dataString(item.Data, "task_title"); title != "" {
+			titles = append(titles, title)
+		}
+	}
+
+	template := fcm.BuildDigestNotification(created, updated, projectName, titles)
+
+	data := map[string]interface{}{
+		"type":          string(constants.NotificationTypeDigest),
+		"digest_id":     digest.ID,
+		"project_id":    digest.ProjectID,
+		"project_name":  projectName,
+		"created_count": created,
+		"updated_count": updated,
+		"click_action":  "OPEN_NOTIFICATIONS",
+	}
+
+	// The latest item carries the most recent token and address of the user.
+	// The key is the digest's, so a replica taking over after a crash
+	// between creating the summary and closing the digest finds the summary
+	// instead of sending a second one.
+	latest := items[0]
+	summary := &models.Notification{
+		NotificationType: constants.NotificationTypeDigest,
+		UserID:           digest.UserID,
+		IdempotencyKey:   "digest:" + digest.ID,
+		FCMToken:         latest.FCMToken,
+		RecipientEmail:   latest.RecipientEmail,
+		Title:            template.Title,
+		Body:             template.Body,
+		Data:             data,
+		ProjectID:        digest.ProjectID,
+		Priority:         constants.PriorityMedium,
+	}
+
+	sendErr := s.notificationService.CreateAndSendNotification(ctx, summary)
+	if summary.ID == "" {
+		return sendErr
+	}
+
+	// Once the summary exists its own retries take over, so the digest is
+	// closed even when the first delivery failed
+	if err := s.repository.MarkSent(ctx, digest.ID, summary.ID); err != nil {
+		return err
+	}
+
+	logger.Info("Sent notification digest",
+		zap.String("digest_id", digest.ID),
+		zap.String("user_id", digest.UserID),
+		zap.String("notification_id", summary.ID),
+		zap.Int("items", len(items)),
+	)
+
+	return sendErr
+}
+
+func dataString(data map[string]interface{}, key string) string {
+	value, _ := data[key].(string)
+	return value
+}
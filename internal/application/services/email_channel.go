@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/corechain/notification-service/internal/domain/interfaces"
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/infrastructure/email"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/pkg/constants"
+)
+
+// EmailChannel delivers notifications by email to the address carried by the
+// event that produced them
+type EmailChannel struct {
+	sender   interfaces.EmailSender
+	renderer *email.Renderer
+}
+
+func NewEmailChannel(sender interfaces.EmailSender, renderer *email.Renderer) *EmailChannel {
+	return &EmailChannel{
+		sender:   sender,
+		renderer: renderer,
+	}
+}
+
+func (c *EmailChannel) Name() string {
+	return constants.ChannelEmail
+}
+
+func (c *EmailChannel) Capabilities() interfaces.ChannelCapabilities {
+	return interfaces.ChannelCapabilities{Direct: true}
+}
+
+func (c *EmailChannel) Send(ctx context.Context, notification *models.Notification) (*interfaces.ChannelResult, error) {
+	if notification.RecipientEmail == "" {
+		return &interfaces.ChannelResult{SkipReason: "no email address"}, nil
+	}
+
+	htmlBody, textBody, err := c.renderer.Render(notification)
+	if err != nil {
+		return nil, errors.NewEmailErrorWithCode(errors.ErrCodeEmail, "failed to render email", err)
+	}
+
+	startedAt := time.Now()
+	messageID, err := c.sender.Send(ctx, interfaces.EmailMessage{
+		To:       notification.RecipientEmail,
+		Subject:  notification.Title,
+		HTMLBody: htmlBody,
+		TextBody: textBody,
+	})
+
+	return &interfaces.ChannelResult{
+		Attempts: []interfaces.ChannelAttempt{{
+			Provider:  constants.ProviderSMTP,
+			MessageID: messageID,
+			StartedAt: startedAt,
+			Latency:   time.Since(startedAt),
+			Err:       err,
+		}},
+	}, nil
+}
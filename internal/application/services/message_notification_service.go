@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+
+	"github.com/corechain/notification-service/internal/application/dto"
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/infrastructure/fcm"
+	"github.com/corechain/notification-service/pkg/constants"
+)
+
+type MessageNotificationService struct {
+	notificationService *NotificationService
+}
+
+func NewMessageNotificationService(notificationService *NotificationService) *MessageNotificationService {
+	return &MessageNotificationService{
+		notificationService: notificationService,
+	}
+}
+
+func (s *MessageNotificationService) ProcessMessageCreatedEvent(ctx context.Context, event *dto.MessageCreatedEvent) error {
+	template := fcm.BuildNewMessageNotification(
+		displayName(event.Metadata.SenderUser),
+		event.Data.Content,
+	)
+
+	data := map[string]interface{}{
+		"type":            "new_message",
+		"message_id":      event.Data.ID,
+		"conversation_id": event.Data.ConversationID,
+		"sender_id":       event.Data.SenderID,
+		"click_action":    "OPEN_CONVERSATION",
+	}
+
+	notification := &models.Notification{
+		NotificationType: constants.NotificationTypeNewMessage,
+		UserID:           event.RecipientID(),
+		FCMToken:         event.Metadata.RecipientUser.FCMToken,
+		RecipientEmail:   event.Metadata.RecipientUser.Email,
+		Title:            template.Title,
+		Body:             template.Body,
+		Data:             data,
+		Priority:         constants.PriorityMedium,
+		SendAt:           event.SendAt,
+	}
+
+	return s.notificationService.CreateAndSendNotification(ctx, notification)
+}
+
+// displayName picks the most readable label available for a user
+func displayName(user models.UserInfo) string {
+	if user.Name != "" {
+		return user.Name
+	}
+	if user.Email != "" {
+		return user.Email
+	}
+	return "Someone"
+}
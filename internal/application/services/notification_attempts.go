@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+
+	"github.com/corechain/notification-service/internal/domain/interfaces"
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"github.com/corechain/notification-service/pkg/constants"
+	"go.uber.org/zap"
+)
+
+// GetNotificationAttempts returns the delivery attempts of a notification, oldest first
+func (s *NotificationService) GetNotificationAttempts(ctx context.Context, notificationID string) ([]*models.NotificationAttempt, error) {
+	if _, err := s.repository.GetByID(ctx, notificationID); err != nil {
+		return nil, err
+	}
+
+	return s.attemptRepository.GetByNotificationID(ctx, notificationID)
+}
+
+// recordAttempt appends a delivery attempt to the log. Failing to record it
+// is logged but never fails the delivery itself.
+func (s *NotificationService) recordAttempt(ctx context.Context, notification *models.Notification, channelAttempt interfaces.ChannelAttempt) {
+	attempt := &models.NotificationAttempt{
+		NotificationID:    notification.ID,
+		AttemptNumber:     notification.RetryCount + 1,
+		Provider:          channelAttempt.Provider,
+		ProviderMessageID: channelAttempt.MessageID,
+		Status:            constants.AttemptStatusSucceeded,
+		LatencyMs:         channelAttempt.Latency.Milliseconds(),
+		CreatedAt:         channelAttempt.StartedAt,
+	}
+
+	if channelAttempt.Err != nil {
+		attempt.Status = constants.AttemptStatusFailed
+		attempt.ErrorCode = errors.CodeOf(channelAttempt.Err)
+		attempt.ErrorMessage = channelAttempt.Err.Error()
+	}
+
+	if err := s.attemptRepository.Create(ctx, attempt); err != nil {
+		logger.Error("Failed to record notification attempt",
+			zap.Error(err),
+			zap.String("notification_id", notification.ID),
+			zap.Int("attempt_number", attempt.AttemptNumber),
+		)
+	}
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/corechain/notification-service/internal/domain/interfaces"
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"github.com/corechain/notification-service/pkg/constants"
+	"go.uber.org/zap"
+)
+
+// sendOnChannel sends a notification over one channel, records its attempts
+// and returns the resulting channel status. The error is the failure that
+// decides how a failed channel is handled.
+func (s *NotificationService) sendOnChannel(ctx context.Context, notification *models.Notification, channel interfaces.Channel) (models.ChannelDelivery, *interfaces.ChannelResult, error) {
+	status := models.ChannelDelivery{
+		Channel:   channel.Name(),
+		UpdatedAt: time.Now(),
+	}
+
+	result, err := channel.Send(ctx, notification)
+	if err != nil {
+		logger.Error("Channel could not send notification",
+			zap.Error(err),
+			zap.String("channel", channel.Name()),
+			zap.String("notification_id", notification.ID),
+		)
+		return failedChannel(status, err), nil, err
+	}
+
+	if result.SkipReason != "" && len(result.Attempts) == 0 {
+		status.Status = constants.ChannelStatusSkipped
+		status.Error = result.SkipReason
+		return status, result, nil
+	}
+
+	var failures []error
+	for _, attempt := range result.Attempts {
+		s.recordAttempt(ctx, notification, attempt)
+		if attempt.Err != nil {
+			failures = append(failures, attempt.Err)
+		}
+	}
+
+	if len(failures) < len(result.Attempts) {
+		status.Status = constants.ChannelStatusSent
+		return status, result, nil
+	}
+
+	err = decisiveError(failures)
+	return failedChannel(status, err), result, err
+}
+
+func failedChannel(status models.ChannelDelivery, err error) models.ChannelDelivery {
+	status.Status = constants.ChannelStatusPermanentlyFailed
+	if errors.IsTransient(err) {
+		status.Status = constants.ChannelStatusFailed
+	}
+	status.Error = err.Error()
+	return status
+}
+
+// decisiveError picks the error that decides how a failed delivery is
+// handled. A transient failure anywhere is worth retrying, so it takes
+// precedence over permanent ones.
+func decisiveError(failures []error) error {
+	var first error
+	for _, err := range failures {
+		if err == nil {
+			continue
+		}
+		if errors.IsTransient(err) {
+			return err
+		}
+		if first == nil {
+			first = err
+		}
+	}
+
+	if first == nil {
+		return errors.NewAppError(errors.ErrCodeInternal, "notification was not delivered", nil)
+	}
+	return first
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/errors"
+)
+
+// listCursor is the JSON behind the opaque next_cursor of the notification list
+type listCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+}
+
+// ListUserNotifications returns one page of the notifications of a user.
+// cursor continues after a next_cursor returned earlier; withTotal also
+// counts every notification matching the filter.
+func (s *NotificationService) ListUserNotifications(ctx context.Context, userID string, query models.NotificationQuery, cursor string, withTotal bool) (*models.NotificationPage, error) {
+	if cursor != "" {
+		after, err := decodeCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		query.After = after
+	}
+
+	// One extra row tells whether another page follows
+	limit := query.Limit
+	query.Limit = limit + 1
+
+	notifications, err := s.repository.GetByUserID(ctx, userID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.NotificationPage{Notifications: notifications}
+	if len(notifications) > limit {
+		page.Notifications = notifications[:limit]
+		page.NextCursor = encodeCursor(notifications[limit-1])
+	}
+	if page.Notifications == nil {
+		page.Notifications = []*models.Notification{}
+	}
+
+	if withTotal {
+		total, err := s.repository.CountByUserID(ctx, userID, query.Filter)
+		if err != nil {
+			return nil, err
+		}
+		page.Total = &total
+	}
+
+	return page, nil
+}
+
+func encodeCursor(notification *models.Notification) string {
+	raw, _ := json.Marshal(listCursor{CreatedAt: notification.CreatedAt, ID: notification.ID})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(cursor string) (*models.NotificationCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.NewInvalidPayloadError("invalid cursor", err)
+	}
+
+	var decoded listCursor
+	if err := json.Unmarshal(raw, &decoded); err != nil || !notificationIDPattern.MatchString(decoded.ID) {
+		return nil, errors.NewInvalidPayloadError("invalid cursor", err)
+	}
+
+	return &models.NotificationCursor{CreatedAt: decoded.CreatedAt, ID: decoded.ID}, nil
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// MarkRead marks a notification as read, which also marks it as seen
+func (s *NotificationService) MarkRead(ctx context.Context, id string) (*models.Notification, error) {
+	return s.repository.MarkRead(ctx, id)
+}
+
+// MarkAllRead marks every unread notification of a user matching filter as
+// read and returns how many changed
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID string, filter models.NotificationFilter) (int64, error) {
+	updated, err := s.repository.MarkAllRead(ctx, userID, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	logger.Info("Marked notifications as read",
+		zap.String("user_id", userID),
+		zap.String("type", string(filter.NotificationType)),
+		zap.String("project_id", filter.ProjectID),
+		zap.Int64("updated", updated),
+	)
+
+	return updated, nil
+}
+
+// MarkAllSeen marks every unseen notification of a user matching filter as
+// seen, typically when the user opens the notification list
+func (s *NotificationService) MarkAllSeen(ctx context.Context, userID string, filter models.NotificationFilter) (int64, error) {
+	return s.repository.MarkAllSeen(ctx, userID, filter)
+}
+
+// GetUnreadCount returns how many notifications of a user are unread and
+// how many of those are not even seen yet
+func (s *NotificationService) GetUnreadCount(ctx context.Context, userID string) (*models.UnreadCount, error) {
+	return s.repository.CountUnread(ctx, userID)
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"github.com/corechain/notification-service/pkg/constants"
+	"go.uber.org/zap"
+)
+
+// RetryPolicy controls how failed deliveries are retried in place
+type RetryPolicy struct {
+	// MaxAttempts is the number of retries after the initial delivery attempt
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// NextDelay returns the exponential backoff for the given retry count, with
+// up to 50% random jitter so failed notifications don't retry in lockstep
+func (p RetryPolicy) NextDelay(retryCount int) time.Duration {
+	delay := p.BaseDelay
+	for i := 0; i < retryCount && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(delay)/2 + 1))
+	return delay + jitter
+}
+
+// RetryFailedNotifications claims failed notifications whose next attempt is
+// due and resends the existing records in place
+func (s *NotificationService) RetryFailedNotifications(ctx context.Context, batchSize int) error {
+	notifications, err := s.repository.ClaimRetryable(ctx, time.Now(), sendLease, batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, notification := range notifications {
+		logger.Info("Retrying notification",
+			zap.String("notification_id", notification.ID),
+			zap.Int("retry_count", notification.RetryCount),
+		)
+
+		// Preferences and quiet hours are checked again for every attempt
+		if err := s.send(ctx, notification); err != nil {
+			logger.Error("Failed to retry notification",
+				zap.Error(err),
+				zap.String("notification_id", notification.ID),
+			)
+		}
+	}
+
+	return nil
+}
+
+// handleDeliveryFailure records a failed delivery. Transient failures with
+// attempts left are scheduled for an in-place retry, anything else marks the
+// notification permanently failed. Either way the failure is reported as
+// handled once it is stored; an error is returned only when the outcome could
+// not be recorded, so the caller retries instead of losing it.
+func (s *NotificationService) handleDeliveryFailure(ctx context.Context, notification *models.Notification, sendErr error) error {
+	if !errors.IsTransient(sendErr) || notification.RetryCount >= s.retryPolicy.MaxAttempts {
+		if err := s.repository.UpdateStatus(ctx, notification.ID, string(constants.StatusPermanentlyFailed), sendErr.Error()); err != nil {
+			logger.Error("Failed to update notification status to permanently failed",
+				zap.Error(err),
+				zap.String("notification_id", notification.ID),
+			)
+			return err
+		}
+
+		logger.Warn("Notification permanently failed",
+			zap.Error(sendErr),
+			zap.String("notification_id", notification.ID),
+			zap.Int("retry_count", notification.RetryCount),
+			zap.String("error_code", errors.CodeOf(sendErr)),
+		)
+		return nil
+	}
+
+	return s.scheduleRetry(ctx, notification, sendErr)
+}
+
+// scheduleRetry hands a notification to the retry worker, which sends it
+// again once the backoff for its retry count has passed
+func (s *NotificationService) scheduleRetry(ctx context.Context, notification *models.Notification, sendErr error) error {
+	nextRetryAt := time.Now().Add(s.retryPolicy.NextDelay(notification.RetryCount))
+	if err := s.repository.ScheduleRetry(ctx, notification.ID, nextRetryAt, sendErr.Error()); err != nil {
+		logger.Error("Failed to schedule notification retry",
+			zap.Error(err),
+			zap.String("notification_id", notification.ID),
+		)
+		return err
+	}
+
+	logger.Warn("Scheduled notification retry",
+		zap.String("notification_id", notification.ID),
+		zap.Int("retry_count", notification.RetryCount),
+		zap.Time("next_retry_at", nextRetryAt),
+	)
+
+	return nil
+}
@@ -2,33 +2,120 @@ package services
 
 import (
 	"context"
-	"fmt"
+	"strings"
 	"time"
 
 	"github.com/corechain/notification-service/internal/domain/interfaces"
 	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/idempotency"
 	"github.com/corechain/notification-service/internal/utils/logger"
 	"github.com/corechain/notification-service/pkg/constants"
 	"go.uber.org/zap"
 )
 
+// CreatedListener is told about every notification once it has been
+// persisted and is due; scheduled notifications are announced at send time
+// and suppressed ones not at all
+type CreatedListener interface {
+	NotificationCreated(ctx context.Context, notification *models.Notification)
+}
+
+// SentListener is told about every notification once it has been sent
+type SentListener interface {
+	NotificationSent(ctx context.Context, notification *models.Notification)
+}
+
+// sendLease is how long a notification is left to the replica sending it
+// before the scheduler or retry worker sends it again; it must outlast a
+// whole batch
+const sendLease = 10 * time.Minute
+
 type NotificationService struct {
-	repository interfaces.NotificationRepository
-	fcmClient  interfaces.FCMClient
+	repository        interfaces.NotificationRepository
+	attemptRepository interfaces.NotificationAttemptRepository
+	preferences       interfaces.NotificationPreferenceRepository
+	digests           interfaces.NotificationDigestRepository
+	digestWindow      time.Duration
+	coalesceWindow    time.Duration
+	bypassPriority    int
+	router            *ChannelRouter
+	retryPolicy       RetryPolicy
+	createdListeners  []CreatedListener
+	sentListeners     []SentListener
 }
 
-func NewNotificationService(repo interfaces.NotificationRepository, fcmClient interfaces.FCMClient) *NotificationService {
+func NewNotificationService(repo interfaces.NotificationRepository, attemptRepo interfaces.NotificationAttemptRepository, preferenceRepo interfaces.NotificationPreferenceRepository, router *ChannelRouter, retryPolicy RetryPolicy) *NotificationService {
 	return &NotificationService{
-		repository: repo,
-		fcmClient:  fcmClient,
+		repository:        repo,
+		attemptRepository: attemptRepo,
+		preferences:       preferenceRepo,
+		router:            router,
+		retryPolicy:       retryPolicy,
+		bypassPriority:    constants.PriorityHigh,
 	}
 }
 
+// AddCreatedListener registers a listener for new notifications. Listeners
+// are called synchronously before the notification is delivered.
+func (s *NotificationService) AddCreatedListener(listener CreatedListener) {
+	s.createdListeners = append(s.createdListeners, listener)
+}
+
+// AddSentListener registers a listener for sent notifications. Listeners are
+// called synchronously after the notification is marked as sent.
+func (s *NotificationService) AddSentListener(listener SentListener) {
+	s.sentListeners = append(s.sentListeners, listener)
+}
+
+// EnableDigests collects the low-priority task notifications of users who
+// opted into the digest instead of sending them; each digest closes window
+// after its first item
+func (s *NotificationService) EnableDigests(repo interfaces.NotificationDigestRepository, window time.Duration) {
+	s.digests = repo
+	s.digestWindow = window
+}
+
+// EnableCoalescing holds task updates back for window, so that a newer
+// update of the same task and kind replaces the waiting one
+func (s *NotificationService) EnableCoalescing(window time.Duration) {
+	s.coalesceWindow = window
+}
+
+// SetQuietHoursBypassPriority lets notifications of the given priority or
+// more urgent through quiet hours and do-not-disturb
+func (s *NotificationService) SetQuietHoursBypassPriority(priority int) {
+	s.bypassPriority = priority
+}
+
+// CreateAndSendNotification persists a notification and sends it right away,
+// or leaves it for the scheduler when SendAt lies in the future or it waits
+// to be coalesced with later updates. A notification the event in ctx
+// already created is only sent again when it never got past pending.
 func (s *NotificationService) CreateAndSendNotification(ctx context.Context, notification *models.Notification) error {
-	notification.CreatedAt = time.Now()
+	now := time.Now()
+	notification.CreatedAt = now
 	notification.Status = constants.StatusPending
+	if notification.IdempotencyKey == "" {
+		notification.IdempotencyKey = idempotency.Key(ctx)
+	}
+
+	scheduled := notification.SendAt != nil && notification.SendAt.After(now)
+	if scheduled {
+		notification.Status = constants.StatusScheduled
+	} else if s.coalesceWindow > 0 && coalescible(notification) {
+		return s.coalesce(ctx, notification, now.Add(s.coalesceWindow))
+	} else {
+		// Should this replica die before the outcome is recorded, the
+		// scheduler sends the notification once the lease expires
+		claimedUntil := now.Add(sendLease)
+		notification.ClaimedUntil = &claimedUntil
+	}
 
 	if err := s.repository.Create(ctx, notification); err != nil {
+		if errors.CodeOf(err) == errors.ErrCodeDuplicate {
+			return s.resumeDuplicate(ctx, notification)
+		}
 		logger.Error("Failed to create notification in database",
 			zap.Error(err),
 			zap.String("user_id", notification.UserID),
@@ -40,74 +127,423 @@ func (s *NotificationService) CreateAndSendNotification(ctx context.Context, not
 		zap.String("id", notification.ID),
 		zap.String("type", string(notification.NotificationType)),
 		zap.String("user_id", notification.UserID),
+		zap.String("status", string(notification.Status)),
 	)
 
-	dataMap := make(map[string]string)
-	for k, v := range notification.Data {
-		dataMap[k] = fmt.Sprintf("%v", v)
+	if scheduled {
+		return nil
 	}
 
-	if err := s.fcmClient.SendNotification(ctx, notification.FCMToken, notification.Title, notification.Body, dataMap); err != nil {
-		// Update status to failed
-		updateErr := s.repository.UpdateStatus(ctx, notification.ID, string(constants.StatusFailed), err.Error())
-		if updateErr != nil {
-			logger.Error("Failed to update notification status to failed",
-				zap.Error(updateErr),
-				zap.String("notification_id", notification.ID),
-			)
-		}
+	return s.send(ctx, notification)
+}
 
-		logger.Error("Failed to send FCM notification",
+// resumeDuplicate takes over the notification the event in ctx already
+// created. An attempt that stored it but died before recording the outcome
+// left it pending; it is sent now unless another replica still holds its
+// lease, in which case the scheduler sends it once the lease expires.
+// Anything past pending is left alone.
+func (s *NotificationService) resumeDuplicate(ctx context.Context, notification *models.Notification) error {
+	existing, err := s.repository.GetByIdempotencyKey(ctx, notification.IdempotencyKey, notification.UserID, notification.NotificationType)
+	if err != nil {
+		logger.Error("Failed to load notification already created for this event",
 			zap.Error(err),
-			zap.String("notification_id", notification.ID),
+			zap.String("idempotency_key", notification.IdempotencyKey),
 			zap.String("user_id", notification.UserID),
 		)
 		return err
 	}
+	*notification = *existing
 
-	if err := s.repository.UpdateStatus(ctx, notification.ID, string(constants.StatusSent), ""); err != nil {
-		logger.Error("Failed to update notification status to sent",
+	if notification.Status != constants.StatusPending {
+		logDuplicate(notification)
+		return nil
+	}
+
+	claimed, err := s.repository.ClaimPending(ctx, notification.ID, time.Now(), sendLease)
+	if err != nil {
+		logger.Error("Failed to claim pending notification",
 			zap.Error(err),
 			zap.String("notification_id", notification.ID),
 		)
 		return err
 	}
+	if !claimed {
+		logger.Info("Notification already created for this event is still being sent",
+			zap.String("id", notification.ID),
+			zap.String("idempotency_key", notification.IdempotencyKey),
+		)
+		return nil
+	}
 
-	logger.Info("Successfully sent notification",
+	logger.Info("Resuming notification already created for this event",
 		zap.String("id", notification.ID),
+		zap.String("idempotency_key", notification.IdempotencyKey),
+		zap.String("type", string(notification.NotificationType)),
 		zap.String("user_id", notification.UserID),
+	)
+	return s.send(ctx, notification)
+}
+
+// logDuplicate records that an event delivered again was not turned into a
+// second notification
+func logDuplicate(notification *models.Notification) {
+	logger.Info("Skipping notification already created for this event",
+		zap.String("idempotency_key", notification.IdempotencyKey),
 		zap.String("type", string(notification.NotificationType)),
+		zap.String("user_id", notification.UserID),
+	)
+}
+
+// coalescible reports whether a notification is an update that a later
+// update of the same task makes obsolete
+func coalescible(notification *models.Notification) bool {
+	if notification.UserID == "" || notification.TaskID == "" {
+		return false
+	}
+	switch notification.NotificationType {
+	case constants.NotificationTypeTaskUpdated, constants.NotificationTypeTaskStatusChanged:
+		return true
+	}
+	return false
+}
+
+// coalesce stores a notification in place of the one still waiting for the
+// same user, task and type; the scheduler sends it once the window closes
+func (s *NotificationService) coalesce(ctx context.Context, notification *models.Notification, sendAt time.Time) error {
+	notification.CoalesceKey = strings.Join([]string{notification.UserID, notification.TaskID, string(notification.NotificationType)}, ":")
+
+	superseded, err := s.repository.CreateCoalesced(ctx, notification, sendAt)
+	if errors.CodeOf(err) == errors.ErrCodeDuplicate {
+		return s.resumeDuplicate(ctx, notification)
+	}
+	if err != nil {
+		logger.Error("Failed to create coalesced notification",
+			zap.Error(err),
+			zap.String("user_id", notification.UserID),
+			zap.String("task_id", notification.TaskID),
+		)
+		return err
+	}
+
+	logger.Info("Created coalesced notification",
+		zap.String("id", notification.ID),
+		zap.String("type", string(notification.NotificationType)),
+		zap.String("user_id", notification.UserID),
+		zap.String("task_id", notification.TaskID),
+		zap.Int64("superseded", superseded),
+		zap.Time("send_at", *notification.SendAt),
 	)
 
 	return nil
 }
 
-func (s *NotificationService) GetUserNotifications(ctx context.Context, userID string, limit, offset int) ([]*models.Notification, error) {
-	return s.repository.GetByUserID(ctx, userID, limit, offset)
+// send applies the recipient's preferences, announces the notification and
+// delivers it. Retried notifications were announced on their first attempt.
+func (s *NotificationService) send(ctx context.Context, notification *models.Notification) error {
+	channels, decision := s.route(ctx, notification)
+	if decision.Suppressed() {
+		return s.suppress(ctx, notification, decision.Reason)
+	}
+	if decision.Digest {
+		return s.collect(ctx, notification)
+	}
+	if decision.DeferUntil != nil {
+		return s.deferUntil(ctx, notification, *decision.DeferUntil)
+	}
+
+	if notification.RetryCount == 0 {
+		s.notifyCreated(ctx, notification)
+	}
+	return s.deliver(ctx, notification, channels)
+}
+
+// route returns the channels a notification goes out on once the
+// recipient's preferences are applied, together with the decision that
+// suppresses or defers it. Preferences can only replace the routed channels
+// with other direct ones, or leave the notification to the digest.
+func (s *NotificationService) route(ctx context.Context, notification *models.Notification) ([]interfaces.Channel, models.PreferenceDecision) {
+	if notification.IsBroadcast() || notification.UserID == "" {
+		return s.router.Route(notification), models.PreferenceDecision{}
+	}
+
+	preferences, err := s.preferences.GetByUserID(ctx, notification.UserID)
+	if err != nil {
+		// Rather deliver as routed than hold notifications back while the
+		// preferences can't be read
+		logger.Error("Failed to load notification preferences",
+			zap.Error(err),
+			zap.String("notification_id", notification.ID),
+			zap.String("user_id", notification.UserID),
+		)
+		return s.router.Route(notification), models.PreferenceDecision{}
+	}
+
+	decision := preferences.Resolve(notification.NotificationType, notification.ProjectID)
+	if decision.Suppressed() {
+		return nil, decision
+	}
+	if s.digests != nil && preferences.Digest && digestible(notification) {
+		decision.Digest = true
+		return nil, decision
+	}
+	if !s.bypassesQuietHours(notification) {
+		decision.DeferUntil = preferences.QuietUntil(time.Now())
+	}
+	if decision.Channels == nil {
+		return s.router.Route(notification), decision
+	}
+
+	channels := s.router.Select(decision.Channels)
+	if len(channels) == 0 {
+		decision.Reason = "no preferred channel is available: " + strings.Join(decision.Channels, ", ")
+	}
+	return channels, decision
+}
+
+// bypassesQuietHours reports whether a notification is urgent enough to
+// reach the user during quiet hours and do-not-disturb. Lower priorities are
+// more urgent; a notification without a priority never bypasses them.
+func (s *NotificationService) bypassesQuietHours(notification *models.Notification) bool {
+	if notification.NotificationType == constants.NotificationTypeIncomingCall {
+		return true
+	}
+	return notification.Priority != 0 && notification.Priority <= s.bypassPriority
 }
 
-func (s *NotificationService) RetryFailedNotifications(ctx context.Context, maxRetries int) error {
-	notifications, err := s.repository.GetPendingNotifications(ctx, 100)
+// digestible reports whether a notification may wait for the recipient's
+// digest instead of being sent on its own
+func digestible(notification *models.Notification) bool {
+	switch notification.NotificationType {
+	case constants.NotificationTypeTaskCreated, constants.NotificationTypeTaskUpdated:
+		return notification.Priority == constants.PriorityLow
+	}
+	return false
+}
+
+// collect adds a notification to the recipient's open digest. It shows up
+// in the inbox right away and is announced by the digest's summary.
+func (s *NotificationService) collect(ctx context.Context, notification *models.Notification) error {
+	digest, err := s.digests.AddItem(ctx, notification, time.Now().Add(s.digestWindow))
 	if err != nil {
+		logger.Error("Failed to add notification to digest",
+			zap.Error(err),
+			zap.String("notification_id", notification.ID),
+		)
 		return err
 	}
 
-	for _, notif := range notifications {
-		if notif.RetryCount >= maxRetries {
-			logger.Warn("Max retries exceeded for notification",
-				zap.String("notification_id", notif.ID),
-				zap.Int("retry_count", notif.RetryCount),
-			)
+	logger.Info("Added notification to digest",
+		zap.String("id", notification.ID),
+		zap.String("user_id", notification.UserID),
+		zap.String("digest_id", digest.ID),
+		zap.Int("items", digest.ItemCount),
+	)
+
+	notification.Status = constants.StatusDigested
+	notification.DigestID = digest.ID
+	notification.NextRetryAt = nil
+	if notification.RetryCount == 0 {
+		s.notifyCreated(ctx, notification)
+	}
+	return nil
+}
+
+// deferUntil holds a notification back until the recipient may be disturbed
+// again; the scheduler sends it then
+func (s *NotificationService) deferUntil(ctx context.Context, notification *models.Notification, until time.Time) error {
+	if err := s.repository.Defer(ctx, notification.ID, until); err != nil {
+		logger.Error("Failed to defer notification",
+			zap.Error(err),
+			zap.String("notification_id", notification.ID),
+		)
+		return err
+	}
+
+	logger.Info("Deferred notification for quiet hours",
+		zap.String("id", notification.ID),
+		zap.String("user_id", notification.UserID),
+		zap.String("type", string(notification.NotificationType)),
+		zap.Time("until", until),
+	)
+
+	notification.Status = constants.StatusDeferred
+	notification.SendAt = &until
+	notification.NextRetryAt = nil
+	return nil
+}
+
+// suppress records that the recipient's preferences held a notification back
+func (s *NotificationService) suppress(ctx context.Context, notification *models.Notification, reason string) error {
+	if err := s.repository.UpdateStatus(ctx, notification.ID, string(constants.StatusSuppressed), reason); err != nil {
+		logger.Error("Failed to update notification status to suppressed",
+			zap.Error(err),
+			zap.String("notification_id", notification.ID),
+		)
+		return err
+	}
+
+	logger.Info("Suppressed notification",
+		zap.String("id", notification.ID),
+		zap.String("user_id", notification.UserID),
+		zap.String("type", string(notification.NotificationType)),
+		zap.String("reason", reason),
+	)
+
+	notification.Status = constants.StatusSuppressed
+	notification.ErrorMessage = reason
+	notification.NextRetryAt = nil
+	return nil
+}
+
+func (s *NotificationService) notifyCreated(ctx context.Context, notification *models.Notification) {
+	for _, listener := range s.createdListeners {
+		listener.NotificationCreated(ctx, notification)
+	}
+}
+
+// deliver sends an already persisted notification over the given channels
+// and records the outcome. A notification that some channels delivered while
+// others failed transiently is retried for the failed ones; channels that
+// already delivered it are not repeated. It counts as sent once no channel is
+// left to retry.
+func (s *NotificationService) deliver(ctx context.Context, notification *models.Notification, channels []interfaces.Channel) error {
+	previous := make(map[string]models.ChannelDelivery, len(notification.Channels))
+	for _, channel := range notification.Channels {
+		previous[channel.Channel] = channel
+	}
+
+	statuses := make([]models.ChannelDelivery, 0, len(channels))
+	deliveries := notification.Deliveries
+	var failures []error
+	var skipReasons []string
+	sent := 0
+
+	for _, channel := range channels {
+		if prev, ok := previous[channel.Name()]; ok && prev.Status == constants.ChannelStatusSent {
+			statuses = append(statuses, prev)
+			sent++
 			continue
 		}
 
-		if err := s.CreateAndSendNotification(ctx, notif); err != nil {
-			logger.Error("Failed to retry notification",
+		status, result, err := s.sendOnChannel(ctx, notification, channel)
+		statuses = append(statuses, status)
+
+		switch status.Status {
+		case constants.ChannelStatusSent:
+			sent++
+		case constants.ChannelStatusSkipped:
+			skipReasons = append(skipReasons, channel.Name()+": "+status.Error)
+		default:
+			failures = append(failures, err)
+		}
+
+		if result != nil && result.Deliveries != nil {
+			deliveries = result.Deliveries
+		}
+	}
+
+	if err := s.repository.UpdateDeliveryResults(ctx, notification.ID, statuses, deliveries); err != nil {
+		logger.Error("Failed to store notification delivery results",
+			zap.Error(err),
+			zap.String("notification_id", notification.ID),
+		)
+	}
+
+	if sent == 0 {
+		if len(failures) == 0 {
+			// Nobody to deliver to; keep the notification in the inbox
+			// without failing the event that produced it
+			reason := "no delivery channel"
+			if len(skipReasons) > 0 {
+				reason = strings.Join(skipReasons, "; ")
+			}
+			logger.Warn("Notification not delivered on any channel",
+				zap.String("notification_id", notification.ID),
+				zap.String("user_id", notification.UserID),
+				zap.String("reason", reason),
+			)
+			return s.repository.UpdateStatus(ctx, notification.ID, string(constants.StatusPermanentlyFailed), reason)
+		}
+
+		sendErr := decisiveError(failures)
+		logger.Error("Failed to deliver notification",
+			zap.Error(sendErr),
+			zap.String("notification_id", notification.ID),
+			zap.String("user_id", notification.UserID),
+			zap.Int("channels", len(channels)),
+			zap.Int("retry_count", notification.RetryCount),
+		)
+		return s.handleDeliveryFailure(ctx, notification, sendErr)
+	}
+
+	if sendErr := decisiveError(failures); len(failures) > 0 && errors.IsTransient(sendErr) && notification.RetryCount < s.retryPolicy.MaxAttempts {
+		logger.Warn("Notification delivered on some channels only",
+			zap.Error(sendErr),
+			zap.String("notification_id", notification.ID),
+			zap.String("user_id", notification.UserID),
+			zap.Int("channels", len(channels)),
+			zap.Int("sent", sent),
+			zap.Int("retry_count", notification.RetryCount),
+		)
+		return s.scheduleRetry(ctx, notification, sendErr)
+	}
+
+	if err := s.repository.UpdateStatus(ctx, notification.ID, string(constants.StatusSent), ""); err != nil {
+		logger.Error("Failed to update notification status to sent",
+			zap.Error(err),
+			zap.String("notification_id", notification.ID),
+		)
+		return err
+	}
+
+	logger.Info("Successfully sent notification",
+		zap.String("id", notification.ID),
+		zap.String("user_id", notification.UserID),
+		zap.String("type", string(notification.NotificationType)),
+		zap.Int("channels", len(channels)),
+		zap.Int("sent", sent),
+	)
+
+	sentAt := time.Now()
+	notification.Status = constants.StatusSent
+	notification.SentAt = &sentAt
+	notification.NextRetryAt = nil
+	notification.Channels = statuses
+	notification.Deliveries = deliveries
+	for _, listener := range s.sentListeners {
+		listener.NotificationSent(ctx, notification)
+	}
+
+	return nil
+}
+
+// ProcessScheduledNotifications claims scheduled and deferred notifications
+// whose send time has passed and sends them, together with pending ones whose
+// sender died before recording the outcome. Claiming is safe across replicas.
+func (s *NotificationService) ProcessScheduledNotifications(ctx context.Context, batchSize int) error {
+	notifications, err := s.repository.ClaimScheduled(ctx, time.Now(), sendLease, batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, notification := range notifications {
+		if err := s.send(ctx, notification); err != nil {
+			logger.Error("Failed to deliver scheduled notification",
 				zap.Error(err),
-				zap.String("notification_id", notif.ID),
+				zap.String("notification_id", notification.ID),
 			)
 		}
 	}
 
 	return nil
 }
+
+// CancelScheduledNotification cancels a notification that is still waiting for its send time
+func (s *NotificationService) CancelScheduledNotification(ctx context.Context, id string) error {
+	if err := s.repository.CancelScheduled(ctx, id); err != nil {
+		return err
+	}
+
+	logger.Info("Cancelled scheduled notification", zap.String("notification_id", id))
+	return nil
+}
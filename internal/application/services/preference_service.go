@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/corechain/notification-service/internal/application/dto"
+	"github.com/corechain/notification-service/internal/domain/interfaces"
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// PreferenceService manages the notification preferences of each user
+type PreferenceService struct {
+	repository    interfaces.NotificationPreferenceRepository
+	notifications interfaces.NotificationRepository
+	registry      *ChannelRegistry
+}
+
+func NewPreferenceService(repo interfaces.NotificationPreferenceRepository, notificationRepo interfaces.NotificationRepository, registry *ChannelRegistry) *PreferenceService {
+	return &PreferenceService{
+		repository:    repo,
+		notifications: notificationRepo,
+		registry:      registry,
+	}
+}
+
+func (s *PreferenceService) GetPreferences(ctx context.Context, userID string) (*models.NotificationPreferences, error) {
+	return s.repository.GetByUserID(ctx, userID)
+}
+
+// UpdatePreferences replaces the preferences of a user. Channels must be
+// registered channels that deliver to a single user, and each scope may only
+// be overridden once.
+func (s *PreferenceService) UpdatePreferences(ctx context.Context, userID string, req *dto.UpdatePreferencesRequest) (*models.NotificationPreferences, error) {
+	if err := s.checkChannels(req.Channels); err != nil {
+		return nil, err
+	}
+
+	preferences := &models.NotificationPreferences{
+		UserID:    userID,
+		Channels:  req.Channels,
+		Muted:     req.Muted,
+		Digest:    req.Digest,
+		Overrides: make([]models.PreferenceOverride, 0, len(req.Overrides)),
+	}
+
+	scopes := make(map[[2]string]bool, len(req.Overrides))
+	for _, override := range req.Overrides {
+		if override.NotificationType == "" && override.ProjectID == "" {
+			return nil, errors.NewInvalidPayloadError("overrides must name a notification type, a project or both", nil)
+		}
+
+		scope := [2]string{override.NotificationType, override.ProjectID}
+		if scopes[scope] {
+			return nil, errors.NewInvalidPayloadError(fmt.Sprintf("duplicate override for notification type %q and project %q", override.NotificationType, override.ProjectID), nil)
+		}
+		scopes[scope] = true
+
+		if err := s.checkChannels(override.Channels); err != nil {
+			return nil, err
+		}
+
+		preferences.Overrides = append(preferences.Overrides, models.PreferenceOverride{
+			NotificationType: models.NotificationType(override.NotificationType),
+			ProjectID:        override.ProjectID,
+			Channels:         override.Channels,
+			Muted:            override.Muted,
+		})
+	}
+
+	if req.QuietHours != nil {
+		quietHours, err := parseQuietHours(req.QuietHours)
+		if err != nil {
+			return nil, err
+		}
+		preferences.QuietHours = quietHours
+	}
+
+	if err := s.repository.Replace(ctx, preferences); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Updated notification preferences",
+		zap.String("user_id", userID),
+		zap.Bool("muted", preferences.Muted),
+		zap.Int("overrides", len(preferences.Overrides)),
+	)
+
+	s.releaseDeferred(ctx, userID)
+	return preferences, nil
+}
+
+// SetDoNotDisturb holds back notifications that aren't urgent until the
+// given time; a time in the past ends do-not-disturb
+func (s *PreferenceService) SetDoNotDisturb(ctx context.Context, userID string, until time.Time) error {
+	if err := s.repository.SetDoNotDisturb(ctx, userID, &until); err != nil {
+		return err
+	}
+
+	logger.Info("Set do not disturb",
+		zap.String("user_id", userID),
+		zap.Time("until", until),
+	)
+
+	s.releaseDeferred(ctx, userID)
+	return nil
+}
+
+func (s *PreferenceService) ClearDoNotDisturb(ctx context.Context, userID string) error {
+	if err := s.repository.SetDoNotDisturb(ctx, userID, nil); err != nil {
+		return err
+	}
+
+	logger.Info("Cleared do not disturb", zap.String("user_id", userID))
+	s.releaseDeferred(ctx, userID)
+	return nil
+}
+
+// ResetPreferences drops every preference of a user, so notifications follow
+// the service's routing again
+func (s *PreferenceService) ResetPreferences(ctx context.Context, userID string) error {
+	if err := s.repository.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	logger.Info("Reset notification preferences", zap.String("user_id", userID))
+	s.releaseDeferred(ctx, userID)
+	return nil
+}
+
+// releaseDeferred hands the notifications held back for a user to the
+// scheduler, which defers them again if the new preferences still say so
+func (s *PreferenceService) releaseDeferred(ctx context.Context, userID string) {
+	released, err := s.notifications.ReleaseDeferred(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to release deferred notifications",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		return
+	}
+
+	if released > 0 {
+		logger.Info("Released deferred notifications",
+			zap.String("user_id", userID),
+			zap.Int64("count", released),
+		)
+	}
+}
+
+func (s *PreferenceService) checkChannels(names []string) error {
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		channel, ok := s.registry.Get(name)
+		if !ok || !channel.Capabilities().Direct {
+			return errors.NewInvalidPayloadError("unknown notification channel: "+name, nil)
+		}
+		if seen[name] {
+			return errors.NewInvalidPayloadError("duplicate notification channel: "+name, nil)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+func parseQuietHours(req *dto.QuietHoursRequest) (*models.QuietHours, error) {
+	start, okStart := models.ParseClock(req.Start)
+	end, okEnd := models.ParseClock(req.End)
+	if !okStart || !okEnd {
+		return nil, errors.NewInvalidPayloadError("quiet hours must be HH:MM clock times", nil)
+	}
+	if start == end {
+		return nil, errors.NewInvalidPayloadError("quiet hours must not start and end at the same time", nil)
+	}
+	if req.TimeZone == "" {
+		return nil, errors.NewInvalidPayloadError("quiet hours need a time zone, e.g. Europe/Berlin", nil)
+	}
+	// "Local" would follow the server's zone rather than the user's
+	if _, err := time.LoadLocation(req.TimeZone); err != nil || req.TimeZone == "Local" {
+		return nil, errors.NewInvalidPayloadError("unknown time zone: "+req.TimeZone, err)
+	}
+
+	return &models.QuietHours{
+		Start:    req.Start,
+		End:      req.End,
+		TimeZone: req.TimeZone,
+	}, nil
+}
@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/corechain/notification-service/internal/domain/interfaces"
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"github.com/corechain/notification-service/pkg/constants"
+	"go.uber.org/zap"
+)
+
+// PushChannel delivers notifications through FCM, either to every device of
+// the user or, for broadcasts, to an FCM topic or condition
+type PushChannel struct {
+	fcmClient     interfaces.FCMClient
+	deviceService *DeviceTokenService
+	// notifications provides the unread count shown as the iOS badge
+	notifications interfaces.NotificationRepository
+}
+
+func NewPushChannel(fcmClient interfaces.FCMClient, deviceService *DeviceTokenService, notifications interfaces.NotificationRepository) *PushChannel {
+	return &PushChannel{
+		fcmClient:     fcmClient,
+		deviceService: deviceService,
+		notifications: notifications,
+	}
+}
+
+func (c *PushChannel) Name() string {
+	return constants.ChannelPush
+}
+
+func (c *PushChannel) Capabilities() interfaces.ChannelCapabilities {
+	return interfaces.ChannelCapabilities{Direct: true, Broadcast: true}
+}
+
+func (c *PushChannel) Send(ctx context.Context, notification *models.Notification) (*interfaces.ChannelResult, error) {
+	if notification.IsBroadcast() {
+		return c.sendToAudience(ctx, notification), nil
+	}
+
+	targets, err := c.deliveryTargets(ctx, notification)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(targets) == 0 {
+		return &interfaces.ChannelResult{SkipReason: "no registered device"}, nil
+	}
+
+	tokens := make([]string, len(targets))
+	for i, target := range targets {
+		tokens[i] = target.Token
+	}
+
+	options := interfaces.FCMSendOptions{
+		Badge:       c.badge(ctx, notification.UserID),
+		CollapseKey: collapseKey(notification),
+	}
+
+	startedAt := time.Now()
+	results, err := c.fcmClient.SendMulticast(ctx, tokens, notification.Title, notification.Body, stringData(notification.Data), options)
+	if err != nil {
+		results = failedResults(tokens, err)
+	}
+	latency := time.Since(startedAt)
+
+	c.pruneDeadTokens(ctx, notification, results)
+
+	result := &interfaces.ChannelResult{
+		Attempts:   make([]interfaces.ChannelAttempt, len(results)),
+		Deliveries: deviceDeliveries(targets, results),
+	}
+	for i, sendResult := range results {
+		result.Attempts[i] = interfaces.ChannelAttempt{
+			Provider:  constants.ProviderFCM,
+			MessageID: sendResult.MessageID,
+			StartedAt: startedAt,
+			Latency:   latency,
+			Err:       sendResult.Err,
+		}
+	}
+
+	return result, nil
+}
+
+// sendToAudience sends a broadcast. FCM fans it out, so there is a single
+// attempt and no per-device breakdown.
+func (c *PushChannel) sendToAudience(ctx context.Context, notification *models.Notification) *interfaces.ChannelResult {
+	startedAt := time.Now()
+	messageID, err := c.fcmClient.SendToAudience(ctx, notification.TargetTopic, notification.TargetCondition, notification.Title, notification.Body, stringData(notification.Data))
+
+	return &interfaces.ChannelResult{
+		Attempts: []interfaces.ChannelAttempt{{
+			Provider:  constants.ProviderFCM,
+			MessageID: messageID,
+			StartedAt: startedAt,
+			Latency:   time.Since(startedAt),
+			Err:       err,
+		}},
+	}
+}
+
+// collapseKey makes a newer notification of the same kind about the same
+// task replace the one still shown, so an update doesn't hide an assignment
+// or a comment about that task
+func collapseKey(notification *models.Notification) string {
+	if notification.TaskID == "" {
+		return ""
+	}
+	return string(notification.NotificationType) + ":" + notification.TaskID
+}
+
+// deliveryTarget is one device a notification is pushed to
+type deliveryTarget struct {
+	DeviceID string
+	Platform string
+	Token    string
+}
+
+// deliveryTargets returns the active devices registered for the user. A token
+// carried by the event itself is added when the registry doesn't know it yet
+// and an earlier attempt didn't find it dead.
+func (c *PushChannel) deliveryTargets(ctx context.Context, notification *models.Notification) ([]deliveryTarget, error) {
+	devices, err := c.deviceService.GetActiveDevices(ctx, notification.UserID)
+	if err != nil {
+		logger.Error("Failed to look up registered devices",
+			zap.Error(err),
+			zap.String("user_id", notification.UserID),
+		)
+		return nil, err
+	}
+
+	targets := make([]deliveryTarget, 0, len(devices)+1)
+	known := false
+	for _, device := range devices {
+		if device.FCMToken == notification.FCMToken {
+			known = true
+		}
+		targets = append(targets, deliveryTarget{
+			DeviceID: device.DeviceID,
+			Platform: device.Platform,
+			Token:    device.FCMToken,
+		})
+	}
+
+	if notification.FCMToken != "" && !known && !isDeadDelivery(notification.Deliveries, notification.FCMToken) {
+		targets = append(targets, deliveryTarget{Token: notification.FCMToken})
+	}
+
+	return targets, nil
+}
+
+// badge returns the unread count of the user, which includes the notification
+// being sent. When the count is unavailable the badge is left unchanged.
+func (c *PushChannel) badge(ctx context.Context, userID string) *int {
+	count, err := c.notifications.CountUnread(ctx, userID)
+	if err != nil {
+		logger.Warn("Failed to count unread notifications for the badge",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		return nil
+	}
+
+	badge := int(count.Unread)
+	return &badge
+}
+
+// pruneDeadTokens invalidates every token FCM reported as unregistered or
+// invalid. Failures are logged; they never fail the delivery itself.
+func (c *PushChannel) pruneDeadTokens(ctx context.Context, notification *models.Notification, results []interfaces.FCMSendResult) {
+	if reason, ok := sharedInvalidToken(results); ok {
+		// Distinct tokens don't all go bad for the same reason at once; the
+		// message is more likely at fault, so keep the devices
+		logger.Warn("Not pruning device tokens all rejected for the same reason",
+			zap.String("notification_id", notification.ID),
+			zap.String("user_id", notification.UserID),
+			zap.Int("tokens", len(results)),
+			zap.String("reason", reason),
+		)
+		return
+	}
+
+	for _, result := range results {
+		if !errors.IsDeadToken(result.Err) {
+			continue
+		}
+
+		if err := c.deviceService.InvalidateToken(ctx, notification.UserID, result.Token, errors.CodeOf(result.Err), notification.ID); err != nil {
+			logger.Error("Failed to invalidate device token",
+				zap.Error(err),
+				zap.String("notification_id", notification.ID),
+				zap.String("user_id", notification.UserID),
+			)
+		}
+	}
+}
+
+// sharedInvalidToken reports whether every token of a send to several devices
+// was rejected as invalid with the same FCM error, and returns that error
+func sharedInvalidToken(results []interfaces.FCMSendResult) (string, bool) {
+	if len(results) < 2 {
+		return "", false
+	}
+
+	reason := ""
+	for i, result := range results {
+		if errors.CodeOf(result.Err) != errors.ErrCodeFCMInvalidToken {
+			return "", false
+		}
+
+		// The FCM error without the message naming the token
+		cause := result.Err.Error()
+		if appErr, ok := result.Err.(*errors.AppError); ok && appErr.Err != nil {
+			cause = appErr.Err.Error()
+		}
+		if i > 0 && cause != reason {
+			return "", false
+		}
+		reason = cause
+	}
+
+	return reason, true
+}
+
+// isDeadDelivery reports whether an earlier delivery found the token dead
+func isDeadDelivery(deliveries []models.DeviceDelivery, token string) bool {
+	for _, delivery := range deliveries {
+		if delivery.Token == token && (delivery.ErrorCode == errors.ErrCodeFCMUnregistered || delivery.ErrorCode == errors.ErrCodeFCMInvalidToken) {
+			return true
+		}
+	}
+	return false
+}
+
+// deviceDeliveries builds the per-device breakdown of a send
+func deviceDeliveries(targets []deliveryTarget, results []interfaces.FCMSendResult) []models.DeviceDelivery {
+	deliveries := make([]models.DeviceDelivery, len(results))
+
+	for i, result := range results {
+		delivery := models.DeviceDelivery{
+			Token:     result.Token,
+			Status:    constants.AttemptStatusSucceeded,
+			MessageID: result.MessageID,
+		}
+		if i < len(targets) {
+			delivery.DeviceID = targets[i].DeviceID
+			delivery.Platform = targets[i].Platform
+		}
+
+		if result.Err != nil {
+			delivery.Status = constants.AttemptStatusFailed
+			delivery.ErrorCode = errors.CodeOf(result.Err)
+			delivery.ErrorMessage = result.Err.Error()
+		}
+
+		deliveries[i] = delivery
+	}
+
+	return deliveries
+}
+
+// failedResults reports every token as failed when the send request itself failed
+func failedResults(tokens []string, err error) []interfaces.FCMSendResult {
+	results := make([]interfaces.FCMSendResult, len(tokens))
+	for i, token := range tokens {
+		results[i] = interfaces.FCMSendResult{Token: token, Err: err}
+	}
+	return results
+}
+
+// stringData flattens notification data into the string map FCM expects
+func stringData(data map[string]interface{}) map[string]string {
+	dataMap := make(map[string]string, len(data))
+	for k, v := range data {
+		dataMap[k] = fmt.Sprintf("%v", v)
+	}
+	return dataMap
+}
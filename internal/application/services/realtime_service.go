@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/corechain/notification-service/internal/domain/interfaces"
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// notificationIDPattern matches notification IDs, which double as stream
+// event IDs and cursor positions
+var notificationIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// dispatchTimeout bounds loading a notification for local subscribers
+const dispatchTimeout = 5 * time.Second
+
+// RealtimeSubscription receives the new notifications of one user on one
+// connection. Done is closed when the subscription is dropped, either
+// because the consumer fell behind or because the service shuts down.
+type RealtimeSubscription struct {
+	userID string
+	events chan *models.Notification
+	done   chan struct{}
+	once   sync.Once
+}
+
+func (s *RealtimeSubscription) Events() <-chan *models.Notification {
+	return s.events
+}
+
+func (s *RealtimeSubscription) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *RealtimeSubscription) close() {
+	s.once.Do(func() { close(s.done) })
+}
+
+// RealtimeService pushes notifications to connected clients the moment they
+// are persisted. Every replica announces its notifications through the
+// broker and delivers the announcements to its own connections.
+type RealtimeService struct {
+	repository  interfaces.NotificationRepository
+	broker      interfaces.RealtimeBroker
+	replayLimit int
+	bufferSize  int
+
+	mu          sync.RWMutex
+	subscribers map[string]map[*RealtimeSubscription]struct{}
+	closed      bool
+}
+
+func NewRealtimeService(repo interfaces.NotificationRepository, broker interfaces.RealtimeBroker, replayLimit int, bufferSize int) *RealtimeService {
+	return &RealtimeService{
+		repository:  repo,
+		broker:      broker,
+		replayLimit: replayLimit,
+		bufferSize:  bufferSize,
+		subscribers: make(map[string]map[*RealtimeSubscription]struct{}),
+	}
+}
+
+// Run receives the announcements of every replica until ctx is done
+func (s *RealtimeService) Run(ctx context.Context) {
+	s.broker.Listen(ctx, s.dispatch)
+}
+
+// NotificationCreated announces a new notification to every replica.
+// Broadcasts have no single user and are not streamed.
+func (s *RealtimeService) NotificationCreated(ctx context.Context, notification *models.Notification) {
+	if notification.UserID == "" {
+		return
+	}
+
+	// Resuming clients are replayed in announcement order, so the position
+	// is taken right before publishing. Without it the notification is still
+	// pushed live, only not replayed.
+	if err := s.repository.MarkAnnounced(ctx, notification.ID); err != nil {
+		logger.Error("Failed to mark notification as announced",
+			zap.Error(err),
+			zap.String("notification_id", notification.ID),
+		)
+	}
+
+	event := interfaces.RealtimeEvent{NotificationID: notification.ID, UserID: notification.UserID}
+	if err := s.broker.Publish(ctx, event); err != nil {
+		logger.Error("Failed to publish realtime event",
+			zap.Error(err),
+			zap.String("notification_id", notification.ID),
+		)
+	}
+}
+
+// Open subscribes to the new notifications of a user and returns the ones
+// announced after lastEventID, in announcement order. The subscription starts before the
+// lookup, so a notification may show up in both; callers skip duplicates.
+func (s *RealtimeService) Open(ctx context.Context, userID string, lastEventID string) (*RealtimeSubscription, []*models.Notification, error) {
+	subscription := s.subscribe(userID)
+
+	if !notificationIDPattern.MatchString(lastEventID) {
+		return subscription, nil, nil
+	}
+
+	missed, err := s.repository.GetAnnouncedAfter(ctx, userID, lastEventID, s.replayLimit)
+	if err != nil {
+		s.Unsubscribe(subscription)
+		return nil, nil, err
+	}
+
+	return subscription, missed, nil
+}
+
+func (s *RealtimeService) Unsubscribe(subscription *RealtimeSubscription) {
+	s.mu.Lock()
+	if subscriptions, ok := s.subscribers[subscription.userID]; ok {
+		delete(subscriptions, subscription)
+		if len(subscriptions) == 0 {
+			delete(s.subscribers, subscription.userID)
+		}
+	}
+	s.mu.Unlock()
+
+	subscription.close()
+}
+
+// Close drops every subscription so open streams end; streams opened
+// afterwards end right away
+func (s *RealtimeService) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for userID, subscriptions := range s.subscribers {
+		for subscription := range subscriptions {
+			subscription.close()
+		}
+		delete(s.subscribers, userID)
+	}
+}
+
+func (s *RealtimeService) subscribe(userID string) *RealtimeSubscription {
+	subscription := &RealtimeSubscription{
+		userID: userID,
+		events: make(chan *models.Notification, s.bufferSize),
+		done:   make(chan struct{}),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		subscription.close()
+		return subscription
+	}
+	if s.subscribers[userID] == nil {
+		s.subscribers[userID] = make(map[*RealtimeSubscription]struct{})
+	}
+	s.subscribers[userID][subscription] = struct{}{}
+
+	return subscription
+}
+
+// dispatch hands an announced notification to the local subscribers of its
+// user. A subscriber whose buffer is full is dropped; its client reconnects
+// with Last-Event-ID and catches up from the database.
+func (s *RealtimeService) dispatch(event interfaces.RealtimeEvent) {
+	s.mu.RLock()
+	subscriptions := make([]*RealtimeSubscription, 0, len(s.subscribers[event.UserID]))
+	for subscription := range s.subscribers[event.UserID] {
+		subscriptions = append(subscriptions, subscription)
+	}
+	s.mu.RUnlock()
+
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dispatchTimeout)
+	defer cancel()
+
+	notification, err := s.repository.GetByID(ctx, event.NotificationID)
+	if err != nil {
+		logger.Error("Failed to load notification for realtime delivery",
+			zap.Error(err),
+			zap.String("notification_id", event.NotificationID),
+		)
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		select {
+		case subscription.events <- notification:
+		default:
+			logger.Warn("Dropping slow realtime subscriber",
+				zap.String("user_id", subscription.userID),
+			)
+			s.Unsubscribe(subscription)
+		}
+	}
+}
@@ -2,24 +2,36 @@ package services
 
 import (
 	"context"
+	"strings"
 
 	"github.com/corechain/notification-service/internal/application/dto"
 	"github.com/corechain/notification-service/internal/domain/models"
 	"github.com/corechain/notification-service/internal/infrastructure/fcm"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
 	"github.com/corechain/notification-service/pkg/constants"
+	"go.uber.org/zap"
 )
 
 type TaskNotificationService struct {
 	notificationService *NotificationService
+	reminderService     *TaskReminderService
 }
 
-func NewTaskNotificationService(notificationService *NotificationService) *TaskNotificationService {
+// NewTaskNotificationService creates the task notification service. The
+// reminder service is optional; when nil, due-date reminders are not tracked.
+func NewTaskNotificationService(notificationService *NotificationService, reminderService *TaskReminderService) *TaskNotificationService {
 	return &TaskNotificationService{
 		notificationService: notificationService,
+		reminderService:     reminderService,
 	}
 }
 
 func (s *TaskNotificationService) ProcessTaskCreatedEvent(ctx context.Context, event *dto.TaskCreatedEvent) error {
+	if err := s.scheduleReminders(ctx, &event.Data, event.Metadata.AssignedToUser); err != nil {
+		return err
+	}
+
 	template := fcm.BuildTaskCreatedNotification(
 		event.Data.Title,
 		event.Data.CreatedBy.Email,
@@ -30,7 +42,9 @@ func (s *TaskNotificationService) ProcessTaskCreatedEvent(ctx context.Context, e
 	data := map[string]interface{}{
 		"type":        "task_created",
 		"task_id":     event.Data.ID,
+		"task_title":  event.Data.Title,
 		"project_id":  event.Data.ProjectID,
+		"project_name": event.Data.ProjectName,
 		"priority":    event.Data.Priority,
 		"click_action": "OPEN_TASK_DETAIL",
 	}
@@ -39,41 +53,231 @@ func (s *TaskNotificationService) ProcessTaskCreatedEvent(ctx context.Context, e
 		NotificationType: constants.NotificationTypeTaskCreated,
 		UserID:           event.Metadata.AssignedToUser.ID,
 		FCMToken:         event.Metadata.AssignedToUser.FCMToken,
+		RecipientEmail:   event.Metadata.AssignedToUser.Email,
 		Title:            template.Title,
 		Body:             template.Body,
 		Data:             data,
 		TaskID:           event.Data.ID,
 		ProjectID:        event.Data.ProjectID,
 		Priority:         event.Data.Priority,
+		SendAt:           event.SendAt,
 	}
 
 	return s.notificationService.CreateAndSendNotification(ctx, notification)
 }
 
-func (s *TaskNotificationService) ProcessTaskUpdatedEvent(ctx context.Context, event *dto.TaskCreatedEvent) error {
+func (s *TaskNotificationService) ProcessTaskUpdatedEvent(ctx context.Context, event *dto.TaskUpdatedEvent) error {
+	if err := s.scheduleReminders(ctx, &event.Data, event.Metadata.AssignedToUser); err != nil {
+		return err
+	}
+
+	assigneeID := event.Metadata.AssignedToUser.ID
+	if assigneeID == event.UpdatedByID() {
+		logger.Info("Skipping task.updated notification for self-made change",
+			zap.String("task_id", event.Data.ID),
+			zap.String("user_id", assigneeID),
+		)
+		return nil
+	}
+
+	updatedByName := ""
+	if event.Data.UpdatedBy != nil {
+		updatedByName = event.Data.UpdatedBy.Email
+	}
+
 	template := fcm.BuildTaskUpdatedNotification(
 		event.Data.Title,
-		event.Data.UpdatedBy.Email,
+		updatedByName,
 	)
 
 	data := map[string]interface{}{
-		"type":        "task_updated",
-		"task_id":     event.Data.ID,
-		"project_id":  event.Data.ProjectID,
-		"click_action": "OPEN_TASK_DETAIL",
+		"type":           "task_updated",
+		"task_id":        event.Data.ID,
+		"task_title":     event.Data.Title,
+		"project_id":     event.Data.ProjectID,
+		"project_name":   event.Data.ProjectName,
+		"changed_fields": strings.Join(event.ChangedFields(), ","),
+		"click_action":   "OPEN_TASK_DETAIL",
 	}
 
 	notification := &models.Notification{
 		NotificationType: constants.NotificationTypeTaskUpdated,
-		UserID:           event.Metadata.AssignedToUser.ID,
+		UserID:           assigneeID,
 		FCMToken:         event.Metadata.AssignedToUser.FCMToken,
+		RecipientEmail:   event.Metadata.AssignedToUser.Email,
 		Title:            template.Title,
 		Body:             template.Body,
 		Data:             data,
 		TaskID:           event.Data.ID,
 		ProjectID:        event.Data.ProjectID,
 		Priority:         event.Data.Priority,
+		SendAt:           event.SendAt,
 	}
 
 	return s.notificationService.CreateAndSendNotification(ctx, notification)
 }
+
+func (s *TaskNotificationService) ProcessTaskDeletedEvent(ctx context.Context, event *dto.TaskLifecycleEvent) error {
+	if err := s.cancelReminders(ctx, event.Data.ID); err != nil {
+		return err
+	}
+
+	template := fcm.BuildTaskDeletedNotification(
+		event.Data.Title,
+		userName(event.Data.DeletedBy),
+	)
+
+	return s.notifyTaskUser(ctx, event, constants.NotificationTypeTaskDeleted, event.Metadata.AssignedToUser, template, "OPEN_TASK_LIST")
+}
+
+func (s *TaskNotificationService) ProcessTaskReassignedEvent(ctx context.Context, event *dto.TaskLifecycleEvent) error {
+	if err := s.scheduleReminders(ctx, &event.Data, event.Metadata.AssignedToUser); err != nil {
+		return err
+	}
+
+	newAssignee := event.Metadata.AssignedToUser
+	previousAssignee := event.Metadata.PreviousAssignedToUser
+
+	if newAssignee.ID == previousAssignee.ID {
+		logger.Info("Skipping task.reassigned notification, assignee unchanged",
+			zap.String("task_id", event.Data.ID),
+		)
+		return nil
+	}
+
+	toNew := fcm.BuildTaskAssignedToYouNotification(event.Data.Title, userName(event.Data.UpdatedBy))
+	toPrevious := fcm.BuildTaskReassignedAwayNotification(event.Data.Title, assignedUserName(newAssignee))
+
+	return retryableError(event,
+		s.notifyTaskUser(ctx, event, constants.NotificationTypeTaskReassigned, newAssignee, toNew, "OPEN_TASK_DETAIL"),
+		s.notifyTaskUser(ctx, event, constants.NotificationTypeTaskReassigned, previousAssignee, toPrevious, "OPEN_TASK_LIST"),
+	)
+}
+
+func (s *TaskNotificationService) ProcessTaskStatusChangedEvent(ctx context.Context, event *dto.TaskLifecycleEvent) error {
+	if err := s.scheduleReminders(ctx, &event.Data, event.Metadata.AssignedToUser); err != nil {
+		return err
+	}
+
+	template := fcm.BuildTaskStatusChangedNotification(
+		event.Data.Title,
+		event.Metadata.PreviousStatus,
+		event.Data.Status,
+		userName(event.Data.UpdatedBy),
+	)
+
+	errs := []error{
+		s.notifyTaskUser(ctx, event, constants.NotificationTypeTaskStatusChanged, event.Metadata.AssignedToUser, template, "OPEN_TASK_DETAIL"),
+	}
+	if event.Metadata.CreatedByUser.ID != event.Metadata.AssignedToUser.ID {
+		errs = append(errs, s.notifyTaskUser(ctx, event, constants.NotificationTypeTaskStatusChanged, event.Metadata.CreatedByUser, template, "OPEN_TASK_DETAIL"))
+	}
+
+	return retryableError(event, errs...)
+}
+
+func (s *TaskNotificationService) ProcessTaskCompletedEvent(ctx context.Context, event *dto.TaskLifecycleEvent) error {
+	if err := s.cancelReminders(ctx, event.Data.ID); err != nil {
+		return err
+	}
+
+	template := fcm.BuildTaskCompletedNotification(
+		event.Data.Title,
+		userName(event.Data.UpdatedBy),
+	)
+
+	return s.notifyTaskUser(ctx, event, constants.NotificationTypeTaskCompleted, event.Metadata.CreatedByUser, template, "OPEN_TASK_DETAIL")
+}
+
+// notifyTaskUser sends a lifecycle notification to one recipient. Recipients
+// that are unknown or triggered the event themselves are skipped.
+func (s *TaskNotificationService) notifyTaskUser(ctx context.Context, event *dto.TaskLifecycleEvent, notificationType constants.NotificationType, recipient dto.AssignedUserInfo, template fcm.Template, clickAction string) error {
+	if recipient.ID == "" || recipient.ID == event.ActorID() {
+		return nil
+	}
+
+	data := map[string]interface{}{
+		"type":         string(notificationType),
+		"task_id":      event.Data.ID,
+		"project_id":   event.Data.ProjectID,
+		"status":       event.Data.Status,
+		"click_action": clickAction,
+	}
+
+	notification := &models.Notification{
+		NotificationType: notificationType,
+		UserID:           recipient.ID,
+		FCMToken:         recipient.FCMToken,
+		RecipientEmail:   recipient.Email,
+		Title:            template.Title,
+		Body:             template.Body,
+		Data:             data,
+		TaskID:           event.Data.ID,
+		ProjectID:        event.Data.ProjectID,
+		Priority:         event.Data.Priority,
+		SendAt:           event.SendAt,
+	}
+
+	return s.notificationService.CreateAndSendNotification(ctx, notification)
+}
+
+// retryableError combines the outcomes of notifying several recipients of one
+// event. Every recipient has been tried by then; permanent failures are only
+// logged, and the first transient one is returned so the event is retried.
+// A replay doesn't notify twice: each recipient's notification is keyed by
+// the event.
+func retryableError(event *dto.TaskLifecycleEvent, errs ...error) error {
+	var transient error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if errors.IsTransient(err) {
+			if transient == nil {
+				transient = err
+			}
+			continue
+		}
+		logger.Warn("Failed to notify task recipient",
+			zap.Error(err),
+			zap.String("event_type", event.EventType),
+			zap.String("task_id", event.Data.ID),
+		)
+	}
+
+	return transient
+}
+
+// scheduleReminders keeps the due-date reminders of a task in line with its
+// latest state. It runs before any notification is sent so a failure is
+// retried without pushing the same notification twice.
+func (s *TaskNotificationService) scheduleReminders(ctx context.Context, task *models.Task, assignee dto.AssignedUserInfo) error {
+	if s.reminderService == nil {
+		return nil
+	}
+	return s.reminderService.ScheduleForTask(ctx, task, assignee)
+}
+
+func (s *TaskNotificationService) cancelReminders(ctx context.Context, taskID string) error {
+	if s.reminderService == nil {
+		return nil
+	}
+	return s.reminderService.CancelForTask(ctx, taskID)
+}
+
+func userName(user *models.UserInfo) string {
+	if user == nil {
+		return ""
+	}
+	if user.Name != "" {
+		return user.Name
+	}
+	return user.Email
+}
+
+func assignedUserName(user dto.AssignedUserInfo) string {
+	if user.Name != "" {
+		return user.Name
+	}
+	return user.Email
+}
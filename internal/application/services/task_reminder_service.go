@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/corechain/notification-service/internal/application/dto"
+	"github.com/corechain/notification-service/internal/domain/interfaces"
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/infrastructure/fcm"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"github.com/corechain/notification-service/pkg/constants"
+	"go.uber.org/zap"
+)
+
+// reminderLease is how long a claimed reminder is left to one replica before
+// another may send it
+const reminderLease = 5 * time.Minute
+
+// reminderOffsets lists how long before the due date each reminder fires
+var reminderOffsets = []struct {
+	reminderType constants.ReminderType
+	before       time.Duration
+}{
+	{constants.ReminderTypeDue24h, 24 * time.Hour},
+	{constants.ReminderTypeDue1h, time.Hour},
+	{constants.ReminderTypeOverdue, 0},
+}
+
+// TaskReminderService remembers task due dates and sends due-soon and overdue
+// reminders to the assignee
+type TaskReminderService struct {
+	repository          interfaces.TaskReminderRepository
+	notificationService *NotificationService
+	batchSize           int
+}
+
+func NewTaskReminderService(repo interfaces.TaskReminderRepository, notificationService *NotificationService, batchSize int) *TaskReminderService {
+	return &TaskReminderService{
+		repository:          repo,
+		notificationService: notificationService,
+		batchSize:           batchSize,
+	}
+}
+
+// ScheduleForTask replaces the pending reminders of a task based on its
+// current due date and assignee. Tasks without a due date, and completed or
+// deleted tasks, end up with no pending reminders.
+func (s *TaskReminderService) ScheduleForTask(ctx context.Context, task *models.Task, assignee dto.AssignedUserInfo) error {
+	if task.DueDate == nil || task.IsDeleted || task.Status == constants.TaskStatusCompleted || assignee.ID == "" {
+		return s.CancelForTask(ctx, task.ID)
+	}
+
+	now := time.Now()
+	var reminders []*models.TaskReminder
+	for _, offset := range reminderOffsets {
+		remindAt := task.DueDate.Add(-offset.before)
+		if !remindAt.After(now) {
+			continue
+		}
+
+		reminders = append(reminders, &models.TaskReminder{
+			TaskID:       task.ID,
+			UserID:       assignee.ID,
+			FCMToken:     assignee.FCMToken,
+			Email:        assignee.Email,
+			TaskTitle:    task.Title,
+			ProjectID:    task.ProjectID,
+			Priority:     task.Priority,
+			DueDate:      *task.DueDate,
+			ReminderType: offset.reminderType,
+			RemindAt:     remindAt,
+			Status:       constants.ReminderStatusPending,
+			CreatedAt:    now,
+		})
+	}
+
+	if err := s.repository.ReplaceForTask(ctx, task.ID, reminders); err != nil {
+		logger.Error("Failed to schedule task reminders",
+			zap.Error(err),
+			zap.String("task_id", task.ID),
+		)
+		return err
+	}
+
+	logger.Debug("Scheduled task reminders",
+		zap.String("task_id", task.ID),
+		zap.String("user_id", assignee.ID),
+		zap.Int("count", len(reminders)),
+	)
+
+	return nil
+}
+
+func (s *TaskReminderService) CancelForTask(ctx context.Context, taskID string) error {
+	if err := s.repository.CancelForTask(ctx, taskID); err != nil {
+		logger.Error("Failed to cancel task reminders",
+			zap.Error(err),
+			zap.String("task_id", taskID),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// ProcessDueReminders claims the reminders that are due and sends them.
+// A reminder is marked sent only once its notification was created; one that
+// failed stays pending and is claimed again when its lease expires. The
+// notification is keyed by the reminder, so a second attempt never notifies
+// twice.
+func (s *TaskReminderService) ProcessDueReminders(ctx context.Context) error {
+	reminders, err := s.repository.ClaimDue(ctx, time.Now(), reminderLease, s.batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, reminder := range reminders {
+		if err := s.sendReminder(ctx, reminder); err != nil {
+			logger.Error("Failed to send task reminder",
+				zap.Error(err),
+				zap.String("reminder_id", reminder.ID),
+				zap.String("task_id", reminder.TaskID),
+			)
+			continue
+		}
+
+		if err := s.repository.MarkSent(ctx, reminder.ID, time.Now()); err != nil {
+			logger.Error("Failed to mark task reminder as sent",
+				zap.Error(err),
+				zap.String("reminder_id", reminder.ID),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (s *TaskReminderService) sendReminder(ctx context.Context, reminder *models.TaskReminder) error {
+	notificationType := constants.NotificationTypeTaskDueSoon
+	var template fcm.Template
+
+	switch reminder.ReminderType {
+	case constants.ReminderTypeDue24h:
+		template = fcm.BuildTaskDueSoonNotification(reminder.TaskTitle, reminder.DueDate, "24h")
+	case constants.ReminderTypeDue1h:
+		template = fcm.BuildTaskDueSoonNotification(reminder.TaskTitle, reminder.DueDate, "1h")
+	default:
+		notificationType = constants.NotificationTypeTaskOverdue
+		template = fcm.BuildTaskOverdueNotification(reminder.TaskTitle, reminder.DueDate)
+	}
+
+	data := map[string]interface{}{
+		"type":          string(notificationType),
+		"task_id":       reminder.TaskID,
+		"project_id":    reminder.ProjectID,
+		"reminder_type": string(reminder.ReminderType),
+		"due_date":      reminder.DueDate.UTC().Format(time.RFC3339),
+		"click_action":  "OPEN_TASK_DETAIL",
+	}
+
+	notification := &models.Notification{
+		NotificationType: notificationType,
+		UserID:           reminder.UserID,
+		FCMToken:         reminder.FCMToken,
+		RecipientEmail:   reminder.Email,
+		Title:            template.Title,
+		Body:             template.Body,
+		Data:             data,
+		TaskID:           reminder.TaskID,
+		ProjectID:        reminder.ProjectID,
+		Priority:         reminder.Priority,
+		IdempotencyKey:   "reminder:" + reminder.ID,
+	}
+
+	return s.notificationService.CreateAndSendNotification(ctx, notification)
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/corechain/notification-service/internal/application/dto"
+	"github.com/corechain/notification-service/internal/domain/interfaces"
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// conditionTopicPattern matches the topic names of an FCM condition such as
+// "'project_a' in topics && !('project_b' in topics)"
+var conditionTopicPattern = regexp.MustCompile(`'([^']+)'\s+in\s+topics`)
+
+// TopicSubscriptionService keeps the FCM topic subscriptions of each user's
+// devices in line with their project and company memberships
+type TopicSubscriptionService struct {
+	repository       interfaces.TopicSubscriptionRepository
+	deviceRepository interfaces.DeviceTokenRepository
+	fcmClient        interfaces.FCMClient
+}
+
+func NewTopicSubscriptionService(repo interfaces.TopicSubscriptionRepository, deviceRepo interfaces.DeviceTokenRepository, fcmClient interfaces.FCMClient) *TopicSubscriptionService {
+	return &TopicSubscriptionService{
+		repository:       repo,
+		deviceRepository: deviceRepo,
+		fcmClient:        fcmClient,
+	}
+}
+
+// ProcessMembershipEvent subscribes or unsubscribes the user's devices to the
+// topic of the project or company the event is about
+func (s *TopicSubscriptionService) ProcessMembershipEvent(ctx context.Context, event *dto.MembershipEvent) error {
+	if event.IsRemoval() {
+		return s.Unsubscribe(ctx, event.Data.UserID, event.Topic())
+	}
+	return s.Subscribe(ctx, event.Data.UserID, event.Topic())
+}
+
+// Subscribe records the membership and subscribes every active device of the
+// user. Devices registered later are subscribed when they register.
+func (s *TopicSubscriptionService) Subscribe(ctx context.Context, userID string, topic string) error {
+	if err := s.repository.Add(ctx, userID, topic); err != nil {
+		return err
+	}
+
+	tokens, err := s.activeTokens(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if len(tokens) > 0 {
+		if err := s.fcmClient.SubscribeToTopic(ctx, tokens, topic); err != nil {
+			return s.handleTopicError(err, "subscribe", userID, topic)
+		}
+	}
+
+	logger.Info("Subscribed user to topic",
+		zap.String("user_id", userID),
+		zap.String("topic", topic),
+		zap.Int("devices", len(tokens)),
+	)
+
+	return nil
+}
+
+func (s *TopicSubscriptionService) Unsubscribe(ctx context.Context, userID string, topic string) error {
+	if err := s.repository.Remove(ctx, userID, topic); err != nil {
+		return err
+	}
+
+	tokens, err := s.activeTokens(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if len(tokens) > 0 {
+		if err := s.fcmClient.UnsubscribeFromTopic(ctx, tokens, topic); err != nil {
+			return s.handleTopicError(err, "unsubscribe", userID, topic)
+		}
+	}
+
+	logger.Info("Unsubscribed user from topic",
+		zap.String("user_id", userID),
+		zap.String("topic", topic),
+		zap.Int("devices", len(tokens)),
+	)
+
+	return nil
+}
+
+// SubscribeDevice subscribes a newly registered or refreshed device to every
+// topic of its user
+func (s *TopicSubscriptionService) SubscribeDevice(ctx context.Context, device *models.DeviceToken) error {
+	return s.updateDevice(ctx, device, "subscribe", s.fcmClient.SubscribeToTopic)
+}
+
+// UnsubscribeDevice removes an unregistered device from every topic of its user
+func (s *TopicSubscriptionService) UnsubscribeDevice(ctx context.Context, device *models.DeviceToken) error {
+	return s.updateDevice(ctx, device, "unsubscribe", s.fcmClient.UnsubscribeFromTopic)
+}
+
+// CountRecipients returns how many users a topic or condition reaches. For a
+// condition this counts the users of every topic it names, so it is an upper
+// bound when the condition combines topics with && or !.
+func (s *TopicSubscriptionService) CountRecipients(ctx context.Context, topic string, condition string) (int, error) {
+	topics := []string{topic}
+	if topic == "" {
+		topics = topics[:0]
+		for _, match := range conditionTopicPattern.FindAllStringSubmatch(condition, -1) {
+			topics = append(topics, match[1])
+		}
+	}
+
+	return s.repository.CountUsers(ctx, topics)
+}
+
+func (s *TopicSubscriptionService) updateDevice(ctx context.Context, device *models.DeviceToken, operation string, apply func(context.Context, []string, string) error) error {
+	topics, err := s.repository.GetTopicsByUserID(ctx, device.UserID)
+	if err != nil {
+		return err
+	}
+
+	for _, topic := range topics {
+		if err := apply(ctx, []string{device.FCMToken}, topic); err != nil {
+			if err := s.handleTopicError(err, operation, device.UserID, topic); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *TopicSubscriptionService) activeTokens(ctx context.Context, userID string) ([]string, error) {
+	devices, err := s.deviceRepository.GetActiveByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]string, len(devices))
+	for i, device := range devices {
+		tokens[i] = device.FCMToken
+	}
+	return tokens, nil
+}
+
+// handleTopicError returns transient FCM errors so the caller can retry. FCM
+// refusing individual tokens is only logged: the membership is stored and the
+// dead tokens are pruned the next time a notification is sent to them.
+func (s *TopicSubscriptionService) handleTopicError(err error, operation string, userID string, topic string) error {
+	if errors.IsTransient(err) {
+		return err
+	}
+
+	logger.Warn("FCM refused topic subscription change",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("user_id", userID),
+		zap.String("topic", topic),
+	)
+	return nil
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/corechain/notification-service/internal/application/dto"
+	"github.com/corechain/notification-service/internal/domain/interfaces"
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/infrastructure/webpush"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"github.com/corechain/notification-service/pkg/constants"
+	"go.uber.org/zap"
+)
+
+// WebPushChannel delivers notifications to every browser the user subscribed
+// with the standard Web Push protocol
+type WebPushChannel struct {
+	client         interfaces.WebPushClient
+	webPushService *WebPushService
+}
+
+func NewWebPushChannel(client interfaces.WebPushClient, webPushService *WebPushService) *WebPushChannel {
+	return &WebPushChannel{
+		client:         client,
+		webPushService: webPushService,
+	}
+}
+
+func (c *WebPushChannel) Name() string {
+	return constants.ChannelWebPush
+}
+
+func (c *WebPushChannel) Capabilities() interfaces.ChannelCapabilities {
+	return interfaces.ChannelCapabilities{Direct: true}
+}
+
+func (c *WebPushChannel) Send(ctx context.Context, notification *models.Notification) (*interfaces.ChannelResult, error) {
+	subscriptions, err := c.webPushService.GetUserSubscriptions(ctx, notification.UserID)
+	if err != nil {
+		logger.Error("Failed to look up web push subscriptions",
+			zap.Error(err),
+			zap.String("user_id", notification.UserID),
+		)
+		return nil, err
+	}
+
+	if len(subscriptions) == 0 {
+		return &interfaces.ChannelResult{SkipReason: "no web push subscription"}, nil
+	}
+
+	payload, err := json.Marshal(dto.WebPushPayload{
+		NotificationID: notification.ID,
+		Type:           string(notification.NotificationType),
+		Title:          notification.Title,
+		Body:           notification.Body,
+		Data:           notification.Data,
+		Priority:       notification.Priority,
+	})
+	if err != nil {
+		return nil, errors.NewWebPushErrorWithCode(errors.ErrCodeWebPush, "failed to marshal web push payload", err)
+	}
+
+	urgency := webPushUrgency(notification.Priority)
+	result := &interfaces.ChannelResult{
+		Attempts: make([]interfaces.ChannelAttempt, 0, len(subscriptions)),
+	}
+
+	for _, subscription := range subscriptions {
+		startedAt := time.Now()
+		messageID, err := c.client.Send(ctx, subscription, payload, urgency)
+		result.Attempts = append(result.Attempts, interfaces.ChannelAttempt{
+			Provider:  constants.ProviderWebPush,
+			MessageID: messageID,
+			StartedAt: startedAt,
+			Latency:   time.Since(startedAt),
+			Err:       err,
+		})
+
+		if errors.IsDeadToken(err) {
+			if err := c.webPushService.RemoveExpired(ctx, subscription, errors.CodeOf(err)); err != nil {
+				logger.Error("Failed to remove expired web push subscription",
+					zap.Error(err),
+					zap.String("notification_id", notification.ID),
+					zap.String("subscription_id", subscription.ID),
+				)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// webPushUrgency lets push services hold back low priority messages on
+// battery-constrained devices
+func webPushUrgency(priority int) string {
+	switch priority {
+	case constants.PriorityHigh:
+		return webpush.UrgencyHigh
+	case constants.PriorityLow:
+		return webpush.UrgencyLow
+	default:
+		return webpush.UrgencyNormal
+	}
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+
+	"github.com/corechain/notification-service/internal/application/dto"
+	"github.com/corechain/notification-service/internal/domain/interfaces"
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/infrastructure/webpush"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// WebPushService manages the Web Push subscriptions of each user's browsers
+type WebPushService struct {
+	repository interfaces.WebPushSubscriptionRepository
+	// publicKey is the VAPID key browsers subscribe with; empty when Web Push
+	// is not configured
+	publicKey string
+	// allowInsecureEndpoints accepts http and private network endpoints, for
+	// local development against a push service stub
+	allowInsecureEndpoints bool
+}
+
+func NewWebPushService(repo interfaces.WebPushSubscriptionRepository, publicKey string, allowInsecureEndpoints bool) *WebPushService {
+	return &WebPushService{
+		repository:             repo,
+		publicKey:              publicKey,
+		allowInsecureEndpoints: allowInsecureEndpoints,
+	}
+}
+
+// PublicKey returns the VAPID public key, or an empty string when Web Push is
+// not configured
+func (s *WebPushService) PublicKey() string {
+	return s.publicKey
+}
+
+// Subscribe registers a browser subscription. The endpoint must be an https
+// URL on a public host, since the service posts to it.
+func (s *WebPushService) Subscribe(ctx context.Context, userID string, req *dto.RegisterWebPushSubscriptionRequest) (*models.WebPushSubscription, error) {
+	if err := webpush.ValidateEndpoint(ctx, req.Endpoint, s.allowInsecureEndpoints); err != nil {
+		return nil, errors.NewInvalidPayloadError("invalid subscription endpoint: "+err.Error(), err)
+	}
+	if err := webpush.ValidateKeys(req.Keys.P256dh, req.Keys.Auth); err != nil {
+		return nil, errors.NewInvalidPayloadError("invalid subscription keys: "+err.Error(), err)
+	}
+
+	subscription := &models.WebPushSubscription{
+		UserID:    userID,
+		Endpoint:  req.Endpoint,
+		P256dh:    req.Keys.P256dh,
+		Auth:      req.Keys.Auth,
+		UserAgent: req.UserAgent,
+	}
+
+	if err := s.repository.Upsert(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Registered web push subscription",
+		zap.String("user_id", userID),
+		zap.String("subscription_id", subscription.ID),
+	)
+
+	return subscription, nil
+}
+
+func (s *WebPushService) GetUserSubscriptions(ctx context.Context, userID string) ([]*models.WebPushSubscription, error) {
+	return s.repository.GetByUserID(ctx, userID)
+}
+
+func (s *WebPushService) Unsubscribe(ctx context.Context, userID string, subscriptionID string) error {
+	if err := s.repository.Delete(ctx, userID, subscriptionID); err != nil {
+		return err
+	}
+
+	logger.Info("Removed web push subscription",
+		zap.String("user_id", userID),
+		zap.String("subscription_id", subscriptionID),
+	)
+
+	return nil
+}
+
+// RemoveExpired deletes a subscription the push service no longer knows
+func (s *WebPushService) RemoveExpired(ctx context.Context, subscription *models.WebPushSubscription, reason string) error {
+	if err := s.repository.DeleteByEndpoint(ctx, subscription.Endpoint); err != nil {
+		return err
+	}
+
+	logger.Warn("Removed expired web push subscription",
+		zap.String("user_id", subscription.UserID),
+		zap.String("subscription_id", subscription.ID),
+		zap.String("reason", reason),
+	)
+
+	return nil
+}
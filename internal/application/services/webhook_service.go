@@ -0,0 +1,374 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/corechain/notification-service/internal/application/dto"
+	"github.com/corechain/notification-service/internal/domain/interfaces"
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"github.com/corechain/notification-service/pkg/constants"
+	"go.uber.org/zap"
+)
+
+// WebhookPolicy controls how webhook deliveries are retried
+type WebhookPolicy struct {
+	// Retry.MaxAttempts is the number of retries after the first attempt
+	Retry RetryPolicy
+	// DisableAfter is the number of consecutive failed attempts after which a
+	// subscription is disabled
+	DisableAfter   int
+	RequestTimeout time.Duration
+}
+
+// WebhookService manages webhook subscriptions and posts a signed JSON copy of
+// every sent notification to the subscriptions that match it
+type WebhookService struct {
+	subscriptions interfaces.WebhookSubscriptionRepository
+	deliveries    interfaces.WebhookDeliveryRepository
+	client        interfaces.WebhookClient
+	policy        WebhookPolicy
+}
+
+func NewWebhookService(subscriptions interfaces.WebhookSubscriptionRepository, deliveries interfaces.WebhookDeliveryRepository, client interfaces.WebhookClient, policy WebhookPolicy) *WebhookService {
+	return &WebhookService{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		client:        client,
+		policy:        policy,
+	}
+}
+
+// CreateSubscription registers a webhook endpoint. The returned subscription
+// is the only place its secret is shown.
+func (s *WebhookService) CreateSubscription(ctx context.Context, req *dto.CreateWebhookRequest) (*models.WebhookSubscription, error) {
+	if err := validateWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		secret = newWebhookSecret()
+	}
+
+	now := time.Now()
+	subscription := &models.WebhookSubscription{
+		URL:              req.URL,
+		Secret:           secret,
+		Description:      req.Description,
+		ProjectID:        req.ProjectID,
+		NotificationType: req.NotificationType,
+		IsActive:         true,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	if err := s.subscriptions.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Created webhook subscription",
+		zap.String("subscription_id", subscription.ID),
+		zap.String("project_id", subscription.ProjectID),
+		zap.String("notification_type", subscription.NotificationType),
+	)
+
+	return subscription, nil
+}
+
+func (s *WebhookService) ListSubscriptions(ctx context.Context, projectID string) ([]*models.WebhookSubscription, error) {
+	subscriptions, err := s.subscriptions.List(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, subscription := range subscriptions {
+		subscription.Secret = ""
+	}
+
+	return subscriptions, nil
+}
+
+func (s *WebhookService) GetSubscription(ctx context.Context, id string) (*models.WebhookSubscription, error) {
+	subscription, err := s.subscriptions.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Secret = ""
+	return subscription, nil
+}
+
+// UpdateSubscription applies the fields set in req. Re-enabling a
+// subscription clears its failure count.
+func (s *WebhookService) UpdateSubscription(ctx context.Context, id string, req *dto.UpdateWebhookRequest) (*models.WebhookSubscription, error) {
+	subscription, err := s.subscriptions.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		if err := validateWebhookURL(*req.URL); err != nil {
+			return nil, err
+		}
+		subscription.URL = *req.URL
+	}
+	if req.Secret != nil {
+		subscription.Secret = *req.Secret
+	}
+	if req.Description != nil {
+		subscription.Description = *req.Description
+	}
+	if req.ProjectID != nil {
+		subscription.ProjectID = *req.ProjectID
+	}
+	if req.NotificationType != nil {
+		subscription.NotificationType = *req.NotificationType
+	}
+	if req.IsActive != nil {
+		if *req.IsActive && !subscription.IsActive {
+			subscription.ConsecutiveFailures = 0
+			subscription.DisabledAt = nil
+			subscription.DisabledReason = ""
+		}
+		subscription.IsActive = *req.IsActive
+	}
+	subscription.UpdatedAt = time.Now()
+
+	if err := s.subscriptions.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Updated webhook subscription",
+		zap.String("subscription_id", subscription.ID),
+		zap.Bool("is_active", subscription.IsActive),
+	)
+
+	if req.Secret == nil {
+		subscription.Secret = ""
+	}
+	return subscription, nil
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id string) error {
+	if err := s.subscriptions.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	logger.Info("Deleted webhook subscription", zap.String("subscription_id", id))
+	return nil
+}
+
+// GetDeliveries returns the latest deliveries of a subscription, newest first
+func (s *WebhookService) GetDeliveries(ctx context.Context, subscriptionID string, limit int) ([]*models.WebhookDelivery, error) {
+	if _, err := s.subscriptions.GetByID(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	return s.deliveries.GetBySubscriptionID(ctx, subscriptionID, limit)
+}
+
+// NotificationSent queues a delivery of the notification for every matching
+// subscription. Failing to queue them is logged but never fails the
+// notification itself.
+func (s *WebhookService) NotificationSent(ctx context.Context, notification *models.Notification) {
+	subscriptions, err := s.subscriptions.GetMatching(ctx, notification.ProjectID, string(notification.NotificationType))
+	if err != nil {
+		logger.Error("Failed to find webhook subscriptions",
+			zap.Error(err),
+			zap.String("notification_id", notification.ID),
+		)
+		return
+	}
+
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	payload, err := webhookPayload(notification)
+	if err != nil {
+		logger.Error("Failed to build webhook payload",
+			zap.Error(err),
+			zap.String("notification_id", notification.ID),
+		)
+		return
+	}
+
+	now := time.Now()
+	deliveries := make([]*models.WebhookDelivery, len(subscriptions))
+	for i, subscription := range subscriptions {
+		deliveries[i] = &models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			NotificationID: notification.ID,
+			Payload:        payload,
+			Status:         constants.WebhookDeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		}
+	}
+
+	if err := s.deliveries.CreateBatch(ctx, deliveries); err != nil {
+		logger.Error("Failed to queue webhook deliveries",
+			zap.Error(err),
+			zap.String("notification_id", notification.ID),
+		)
+		return
+	}
+
+	logger.Debug("Queued webhook deliveries",
+		zap.String("notification_id", notification.ID),
+		zap.Int("count", len(deliveries)),
+	)
+}
+
+// ProcessDueDeliveries claims webhook deliveries whose next attempt is due and
+// posts them. Claiming is safe across replicas.
+func (s *WebhookService) ProcessDueDeliveries(ctx context.Context, batchSize int) error {
+	// Deliveries are posted one after another, so the lease must outlast the
+	// whole batch timing out
+	lease := s.policy.RequestTimeout*time.Duration(batchSize) + time.Minute
+
+	deliveries, err := s.deliveries.ClaimDue(ctx, time.Now(), lease, batchSize)
+	if err != nil {
+		return err
+	}
+
+	subscriptions := make(map[string]*models.WebhookSubscription)
+	for _, delivery := range deliveries {
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			subscription, err = s.subscriptions.GetByID(ctx, delivery.SubscriptionID)
+			if err != nil {
+				logger.Error("Failed to load webhook subscription",
+					zap.Error(err),
+					zap.String("subscription_id", delivery.SubscriptionID),
+				)
+				continue
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+
+		if !subscription.IsActive {
+			continue
+		}
+
+		s.deliver(ctx, subscription, delivery)
+	}
+
+	return nil
+}
+
+// deliver posts one delivery and records the outcome. Failed deliveries are
+// retried with exponential backoff until the retries run out, and the
+// subscription is disabled once too many attempts in a row have failed.
+func (s *WebhookService) deliver(ctx context.Context, subscription *models.WebhookSubscription, delivery *models.WebhookDelivery) {
+	statusCode, sendErr := s.client.Post(ctx, subscription.URL, subscription.Secret, delivery.ID, []byte(delivery.Payload))
+	if sendErr == nil {
+		if err := s.deliveries.MarkDelivered(ctx, delivery.ID, statusCode); err != nil {
+			logger.Error("Failed to mark webhook delivery as delivered",
+				zap.Error(err),
+				zap.String("delivery_id", delivery.ID),
+			)
+		}
+		if subscription.ConsecutiveFailures > 0 {
+			if err := s.subscriptions.RecordSuccess(ctx, subscription.ID); err != nil {
+				logger.Error("Failed to reset webhook failure count",
+					zap.Error(err),
+					zap.String("subscription_id", subscription.ID),
+				)
+			}
+			subscription.ConsecutiveFailures = 0
+		}
+		return
+	}
+
+	logger.Warn("Webhook delivery failed",
+		zap.Error(sendErr),
+		zap.String("delivery_id", delivery.ID),
+		zap.String("subscription_id", subscription.ID),
+		zap.Int("status_code", statusCode),
+		zap.Int("attempt", delivery.AttemptCount+1),
+	)
+
+	var err error
+	if delivery.AttemptCount >= s.policy.Retry.MaxAttempts {
+		err = s.deliveries.MarkFailed(ctx, delivery.ID, statusCode, sendErr.Error())
+	} else {
+		nextAttemptAt := time.Now().Add(s.policy.Retry.NextDelay(delivery.AttemptCount))
+		err = s.deliveries.ScheduleRetry(ctx, delivery.ID, nextAttemptAt, statusCode, sendErr.Error())
+	}
+	if err != nil {
+		logger.Error("Failed to record webhook delivery failure",
+			zap.Error(err),
+			zap.String("delivery_id", delivery.ID),
+		)
+	}
+
+	reason := fmt.Sprintf("disabled after %d consecutive failed deliveries: %s", s.policy.DisableAfter, sendErr.Error())
+	disabled, err := s.subscriptions.RecordFailure(ctx, subscription.ID, s.policy.DisableAfter, reason)
+	if err != nil {
+		logger.Error("Failed to record webhook failure",
+			zap.Error(err),
+			zap.String("subscription_id", subscription.ID),
+		)
+		return
+	}
+	subscription.ConsecutiveFailures++
+
+	if disabled {
+		subscription.IsActive = false
+		if err := s.deliveries.FailPending(ctx, subscription.ID, "subscription disabled"); err != nil {
+			logger.Error("Failed to fail pending webhook deliveries",
+				zap.Error(err),
+				zap.String("subscription_id", subscription.ID),
+			)
+		}
+		logger.Warn("Disabled webhook subscription after repeated failures",
+			zap.String("subscription_id", subscription.ID),
+			zap.String("url", subscription.URL),
+			zap.Int("consecutive_failures", subscription.ConsecutiveFailures),
+		)
+	}
+}
+
+// webhookPayload serialises the allow-listed fields of the notification for
+// webhook endpoints
+func webhookPayload(notification *models.Notification) (string, error) {
+	sentAt := time.Now()
+	if notification.SentAt != nil {
+		sentAt = *notification.SentAt
+	}
+
+	payload, err := json.Marshal(dto.WebhookPayload{
+		Event:        constants.EventTypeNotificationSent,
+		SentAt:       sentAt,
+		Notification: dto.NewWebhookNotification(notification),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(payload), nil
+}
+
+func validateWebhookURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.NewInvalidPayloadError("webhook URL must be an absolute http or https URL", err)
+	}
+	return nil
+}
+
+// newWebhookSecret returns a random 32-byte secret, hex encoded
+func newWebhookSecret() string {
+	secret := make([]byte, 32)
+	_, _ = rand.Read(secret)
+	return hex.EncodeToString(secret)
+}
@@ -4,19 +4,31 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/corechain/notification-service/pkg/constants"
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
 )
 
 // Config holds all configuration for the application
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
-	Kafka    KafkaConfig    `mapstructure:"kafka"`
-	FCM      FCMConfig      `mapstructure:"fcm"`
-	Logger   LoggerConfig   `mapstructure:"logger"`
-	Retry    RetryConfig    `mapstructure:"retry"`
+	Server     ServerConfig     `mapstructure:"server"`
+	Database   DatabaseConfig   `mapstructure:"database"`
+	Kafka      KafkaConfig      `mapstructure:"kafka"`
+	FCM        FCMConfig        `mapstructure:"fcm"`
+	Logger     LoggerConfig     `mapstructure:"logger"`
+	Retry      RetryConfig      `mapstructure:"retry"`
+	Reminder   ReminderConfig   `mapstructure:"reminder"`
+	Scheduler  SchedulerConfig  `mapstructure:"scheduler"`
+	Channels   ChannelsConfig   `mapstructure:"channels"`
+	Email      EmailConfig      `mapstructure:"email"`
+	WebPush    WebPushConfig    `mapstructure:"web_push"`
+	Webhook    WebhookConfig    `mapstructure:"webhook"`
+	Realtime   RealtimeConfig   `mapstructure:"realtime"`
+	Digest     DigestConfig     `mapstructure:"digest"`
+	Coalesce   CoalesceConfig   `mapstructure:"coalesce"`
+	QuietHours QuietHoursConfig `mapstructure:"quiet_hours"`
 }
 
 // ServerConfig holds HTTP server configuration
@@ -42,13 +54,35 @@ type KafkaConfig struct {
 	Topics             TopicsConfig  `mapstructure:"topics"`
 	AutoOffsetReset    string        `mapstructure:"auto_offset_reset"`
 	EnableAutoCommit   bool          `mapstructure:"enable_auto_commit"`
+	DeadLetter         DeadLetterConfig `mapstructure:"dead_letter"`
+	RetryTiers         []RetryTierConfig `mapstructure:"retry_tiers"`
+}
+
+// RetryTierConfig describes a delayed retry topic; label "30s" maps to <topic>.retry.30s
+type RetryTierConfig struct {
+	Label string        `mapstructure:"label"`
+	Delay time.Duration `mapstructure:"delay"`
+}
+
+// DeadLetterConfig holds dead-letter topic configuration for failed messages
+type DeadLetterConfig struct {
+	Enabled        bool              `mapstructure:"enabled"`
+	TopicSuffix    string            `mapstructure:"topic_suffix"`
+	Topics         map[string]string `mapstructure:"topics"`
+	MaxAttempts    int               `mapstructure:"max_attempts"`
+	RetryBackoffMs int               `mapstructure:"retry_backoff_ms"`
 }
 
 // TopicsConfig holds Kafka topic names
 type TopicsConfig struct {
 	TaskCreated   string `mapstructure:"task_created"`
+	TaskUpdated   string `mapstructure:"task_updated"`
+	TaskEvents    string `mapstructure:"task_events"`
 	NewMessage    string `mapstructure:"new_message"`
 	IncomingCall  string `mapstructure:"incoming_call"`
+	Membership    string `mapstructure:"membership"`
+	// DeviceTokenInvalidated is produced to, not consumed
+	DeviceTokenInvalidated string `mapstructure:"device_token_invalidated"`
 }
 
 // FCMConfig holds Firebase Cloud Messaging configuration
@@ -64,8 +98,97 @@ type LoggerConfig struct {
 
 // RetryConfig holds retry mechanism configuration
 type RetryConfig struct {
-	MaxAttempts  int `mapstructure:"max_attempts"`
-	DelaySeconds int `mapstructure:"delay_seconds"`
+	MaxAttempts         int `mapstructure:"max_attempts"`
+	DelaySeconds        int `mapstructure:"delay_seconds"`
+	MaxDelaySeconds     int `mapstructure:"max_delay_seconds"`
+	PollIntervalSeconds int `mapstructure:"poll_interval_seconds"`
+	BatchSize           int `mapstructure:"batch_size"`
+}
+
+// ReminderConfig holds due-date reminder scheduler configuration
+type ReminderConfig struct {
+	Enabled             bool `mapstructure:"enabled"`
+	PollIntervalSeconds int  `mapstructure:"poll_interval_seconds"`
+	BatchSize           int  `mapstructure:"batch_size"`
+}
+
+// SchedulerConfig holds scheduled notification delivery configuration
+type SchedulerConfig struct {
+	PollIntervalSeconds int `mapstructure:"poll_interval_seconds"`
+	BatchSize           int `mapstructure:"batch_size"`
+}
+
+// ChannelsConfig decides which delivery channels each notification type uses.
+// Types without a route use the default channels.
+type ChannelsConfig struct {
+	Default []string            `mapstructure:"default"`
+	Routes  map[string][]string `mapstructure:"routes"`
+}
+
+// EmailConfig holds the SMTP settings of the email channel
+type EmailConfig struct {
+	Enabled        bool   `mapstructure:"enabled"`
+	SMTPHost       string `mapstructure:"smtp_host"`
+	SMTPPort       int    `mapstructure:"smtp_port"`
+	Username       string `mapstructure:"username"`
+	Password       string `mapstructure:"password"`
+	FromAddress    string `mapstructure:"from_address"`
+	FromName       string `mapstructure:"from_name"`
+	TLSMode        string `mapstructure:"tls_mode"`
+	TimeoutSeconds int    `mapstructure:"timeout_seconds"`
+}
+
+// WebPushConfig holds the browser Web Push channel configuration
+type WebPushConfig struct {
+	Enabled         bool   `mapstructure:"enabled"`
+	VAPIDPublicKey  string `mapstructure:"vapid_public_key"`
+	VAPIDPrivateKey string `mapstructure:"vapid_private_key"`
+	Subject         string `mapstructure:"subject"`
+	TTLSeconds      int    `mapstructure:"ttl_seconds"`
+	TimeoutSeconds  int    `mapstructure:"timeout_seconds"`
+	// AllowInsecureEndpoints accepts http and private network subscription
+	// endpoints; only for local development against the push service stub
+	AllowInsecureEndpoints bool `mapstructure:"allow_insecure_endpoints"`
+}
+
+// WebhookConfig holds outbound webhook delivery configuration
+type WebhookConfig struct {
+	TimeoutSeconds       int `mapstructure:"timeout_seconds"`
+	MaxAttempts          int `mapstructure:"max_attempts"`
+	RetryDelaySeconds    int `mapstructure:"retry_delay_seconds"`
+	RetryMaxDelaySeconds int `mapstructure:"retry_max_delay_seconds"`
+	DisableAfterFailures int `mapstructure:"disable_after_failures"`
+	PollIntervalSeconds  int `mapstructure:"poll_interval_seconds"`
+	BatchSize            int `mapstructure:"batch_size"`
+}
+
+// RealtimeConfig holds WebSocket and SSE stream configuration
+type RealtimeConfig struct {
+	Channel          string `mapstructure:"channel"`
+	ReplayLimit      int    `mapstructure:"replay_limit"`
+	HeartbeatSeconds int    `mapstructure:"heartbeat_seconds"`
+	BufferSize       int    `mapstructure:"buffer_size"`
+}
+
+// DigestConfig holds the settings of the opt-in notification digest
+type DigestConfig struct {
+	Enabled             bool `mapstructure:"enabled"`
+	WindowMinutes       int  `mapstructure:"window_minutes"`
+	PollIntervalSeconds int  `mapstructure:"poll_interval_seconds"`
+	BatchSize           int  `mapstructure:"batch_size"`
+}
+
+// CoalesceConfig holds how long repeated updates of a task are held back so
+// only the latest one is sent; zero turns coalescing off
+type CoalesceConfig struct {
+	WindowSeconds int `mapstructure:"window_seconds"`
+}
+
+// QuietHoursConfig holds which notifications reach users during quiet hours.
+// Priorities run from 1 (high) to 3 (low); notifications at BypassPriority or
+// more urgent are delivered right away, as are incoming calls.
+type QuietHoursConfig struct {
+	BypassPriority int `mapstructure:"bypass_priority"`
 }
 
 // Load reads configuration from .env file and environment variables
@@ -85,6 +208,47 @@ func Load() (*Config, error) {
 	viper.SetDefault("logger.level", "info")
 	viper.SetDefault("retry.max_attempts", 3)
 	viper.SetDefault("retry.delay_seconds", 5)
+	viper.SetDefault("retry.max_delay_seconds", 3600)
+	viper.SetDefault("retry.poll_interval_seconds", 10)
+	viper.SetDefault("retry.batch_size", 100)
+	viper.SetDefault("reminder.enabled", true)
+	viper.SetDefault("reminder.poll_interval_seconds", 60)
+	viper.SetDefault("reminder.batch_size", 100)
+	viper.SetDefault("scheduler.poll_interval_seconds", 15)
+	viper.SetDefault("channels.default", "push")
+	viper.SetDefault("scheduler.batch_size", 100)
+	viper.SetDefault("email.enabled", false)
+	viper.SetDefault("email.smtp_port", 587)
+	viper.SetDefault("email.from_name", "CoreChain")
+	viper.SetDefault("email.tls_mode", "starttls")
+	viper.SetDefault("email.timeout_seconds", 10)
+	viper.SetDefault("web_push.enabled", false)
+	viper.SetDefault("web_push.ttl_seconds", 86400)
+	viper.SetDefault("web_push.timeout_seconds", 10)
+	viper.SetDefault("web_push.allow_insecure_endpoints", false)
+	viper.SetDefault("webhook.timeout_seconds", 10)
+	viper.SetDefault("webhook.max_attempts", 8)
+	viper.SetDefault("webhook.retry_delay_seconds", 30)
+	viper.SetDefault("webhook.retry_max_delay_seconds", 3600)
+	viper.SetDefault("webhook.disable_after_failures", 20)
+	viper.SetDefault("webhook.poll_interval_seconds", 10)
+	viper.SetDefault("webhook.batch_size", 50)
+	viper.SetDefault("realtime.channel", "notification_events")
+	viper.SetDefault("realtime.replay_limit", 100)
+	viper.SetDefault("realtime.heartbeat_seconds", 25)
+	viper.SetDefault("realtime.buffer_size", 64)
+	viper.SetDefault("digest.enabled", true)
+	viper.SetDefault("digest.window_minutes", 60)
+	viper.SetDefault("digest.poll_interval_seconds", 30)
+	viper.SetDefault("digest.batch_size", 100)
+	viper.SetDefault("coalesce.window_seconds", 10)
+	viper.SetDefault("quiet_hours.bypass_priority", constants.PriorityHigh)
+	viper.SetDefault("kafka.dead_letter.enabled", true)
+	viper.SetDefault("kafka.dead_letter.topic_suffix", ".dlq")
+	viper.SetDefault("kafka.dead_letter.max_attempts", 3)
+	viper.SetDefault("kafka.dead_letter.retry_backoff_ms", 500)
+	viper.SetDefault("kafka.retry_tiers", "30s,5m")
+	viper.SetDefault("kafka.topics.device_token_invalidated", "device.token.invalidated")
 
 	// Enable environment variable reading
 	viper.AutomaticEnv()
@@ -102,15 +266,68 @@ func Load() (*Config, error) {
 	viper.BindEnv("kafka.brokers", "KAFKA_BROKERS")
 	viper.BindEnv("kafka.group_id", "KAFKA_GROUP_ID")
 	viper.BindEnv("kafka.topics.task_created", "KAFKA_TOPIC_TASK_CREATED")
+	viper.BindEnv("kafka.topics.task_updated", "KAFKA_TOPIC_TASK_UPDATED")
+	viper.BindEnv("kafka.topics.task_events", "KAFKA_TOPIC_TASK_EVENTS")
 	viper.BindEnv("kafka.topics.new_message", "KAFKA_TOPIC_NEW_MESSAGE")
 	viper.BindEnv("kafka.topics.incoming_call", "KAFKA_TOPIC_INCOMING_CALL")
+	viper.BindEnv("kafka.topics.membership", "KAFKA_TOPIC_MEMBERSHIP")
+	viper.BindEnv("kafka.topics.device_token_invalidated", "KAFKA_TOPIC_DEVICE_TOKEN_INVALIDATED")
 	viper.BindEnv("kafka.auto_offset_reset", "KAFKA_AUTO_OFFSET_RESET")
 	viper.BindEnv("kafka.enable_auto_commit", "KAFKA_ENABLE_AUTO_COMMIT")
+	viper.BindEnv("kafka.dead_letter.enabled", "KAFKA_DLQ_ENABLED")
+	viper.BindEnv("kafka.dead_letter.topic_suffix", "KAFKA_DLQ_TOPIC_SUFFIX")
+	viper.BindEnv("kafka.dead_letter.topics", "KAFKA_DLQ_TOPICS")
+	viper.BindEnv("kafka.dead_letter.max_attempts", "KAFKA_DLQ_MAX_ATTEMPTS")
+	viper.BindEnv("kafka.dead_letter.retry_backoff_ms", "KAFKA_DLQ_RETRY_BACKOFF_MS")
+	viper.BindEnv("kafka.retry_tiers", "KAFKA_RETRY_TIERS")
 	viper.BindEnv("fcm.credentials_path", "FCM_CREDENTIALS_PATH")
 	viper.BindEnv("fcm.project_id", "FCM_PROJECT_ID")
 	viper.BindEnv("logger.level", "LOG_LEVEL")
 	viper.BindEnv("retry.max_attempts", "MAX_RETRY_ATTEMPTS")
 	viper.BindEnv("retry.delay_seconds", "RETRY_DELAY_SECONDS")
+	viper.BindEnv("retry.max_delay_seconds", "RETRY_MAX_DELAY_SECONDS")
+	viper.BindEnv("retry.poll_interval_seconds", "RETRY_POLL_INTERVAL_SECONDS")
+	viper.BindEnv("retry.batch_size", "RETRY_BATCH_SIZE")
+	viper.BindEnv("reminder.enabled", "REMINDER_ENABLED")
+	viper.BindEnv("reminder.poll_interval_seconds", "REMINDER_POLL_INTERVAL_SECONDS")
+	viper.BindEnv("reminder.batch_size", "REMINDER_BATCH_SIZE")
+	viper.BindEnv("scheduler.poll_interval_seconds", "SCHEDULER_POLL_INTERVAL_SECONDS")
+	viper.BindEnv("scheduler.batch_size", "SCHEDULER_BATCH_SIZE")
+	viper.BindEnv("channels.default", "NOTIFICATION_CHANNELS_DEFAULT")
+	viper.BindEnv("channels.routes", "NOTIFICATION_CHANNEL_ROUTES")
+	viper.BindEnv("email.enabled", "EMAIL_ENABLED")
+	viper.BindEnv("email.smtp_host", "SMTP_HOST")
+	viper.BindEnv("email.smtp_port", "SMTP_PORT")
+	viper.BindEnv("email.username", "SMTP_USERNAME")
+	viper.BindEnv("email.password", "SMTP_PASSWORD")
+	viper.BindEnv("email.from_address", "SMTP_FROM_ADDRESS")
+	viper.BindEnv("email.from_name", "SMTP_FROM_NAME")
+	viper.BindEnv("email.tls_mode", "SMTP_TLS_MODE")
+	viper.BindEnv("email.timeout_seconds", "SMTP_TIMEOUT_SECONDS")
+	viper.BindEnv("web_push.enabled", "WEB_PUSH_ENABLED")
+	viper.BindEnv("web_push.vapid_public_key", "VAPID_PUBLIC_KEY")
+	viper.BindEnv("web_push.vapid_private_key", "VAPID_PRIVATE_KEY")
+	viper.BindEnv("web_push.subject", "VAPID_SUBJECT")
+	viper.BindEnv("web_push.ttl_seconds", "WEB_PUSH_TTL_SECONDS")
+	viper.BindEnv("web_push.timeout_seconds", "WEB_PUSH_TIMEOUT_SECONDS")
+	viper.BindEnv("web_push.allow_insecure_endpoints", "WEB_PUSH_ALLOW_INSECURE_ENDPOINTS")
+	viper.BindEnv("webhook.timeout_seconds", "WEBHOOK_TIMEOUT_SECONDS")
+	viper.BindEnv("webhook.max_attempts", "WEBHOOK_MAX_ATTEMPTS")
+	viper.BindEnv("webhook.retry_delay_seconds", "WEBHOOK_RETRY_DELAY_SECONDS")
+	viper.BindEnv("webhook.retry_max_delay_seconds", "WEBHOOK_RETRY_MAX_DELAY_SECONDS")
+	viper.BindEnv("webhook.disable_after_failures", "WEBHOOK_DISABLE_AFTER_FAILURES")
+	viper.BindEnv("webhook.poll_interval_seconds", "WEBHOOK_POLL_INTERVAL_SECONDS")
+	viper.BindEnv("webhook.batch_size", "WEBHOOK_BATCH_SIZE")
+	viper.BindEnv("realtime.channel", "REALTIME_CHANNEL")
+	viper.BindEnv("realtime.replay_limit", "REALTIME_REPLAY_LIMIT")
+	viper.BindEnv("realtime.heartbeat_seconds", "REALTIME_HEARTBEAT_SECONDS")
+	viper.BindEnv("realtime.buffer_size", "REALTIME_BUFFER_SIZE")
+	viper.BindEnv("digest.enabled", "DIGEST_ENABLED")
+	viper.BindEnv("digest.window_minutes", "DIGEST_WINDOW_MINUTES")
+	viper.BindEnv("digest.poll_interval_seconds", "DIGEST_POLL_INTERVAL_SECONDS")
+	viper.BindEnv("digest.batch_size", "DIGEST_BATCH_SIZE")
+	viper.BindEnv("coalesce.window_seconds", "COALESCE_WINDOW_SECONDS")
+	viper.BindEnv("quiet_hours.bypass_priority", "QUIET_HOURS_BYPASS_PRIORITY")
 
 	// Create config struct and populate from environment
 	var config Config
@@ -136,10 +353,42 @@ func Load() (*Config, error) {
 	}
 	config.Kafka.GroupID = viper.GetString("kafka.group_id")
 	config.Kafka.Topics.TaskCreated = viper.GetString("kafka.topics.task_created")
+	config.Kafka.Topics.TaskUpdated = viper.GetString("kafka.topics.task_updated")
+	config.Kafka.Topics.TaskEvents = viper.GetString("kafka.topics.task_events")
 	config.Kafka.Topics.NewMessage = viper.GetString("kafka.topics.new_message")
 	config.Kafka.Topics.IncomingCall = viper.GetString("kafka.topics.incoming_call")
+	config.Kafka.Topics.Membership = viper.GetString("kafka.topics.membership")
+	config.Kafka.Topics.DeviceTokenInvalidated = viper.GetString("kafka.topics.device_token_invalidated")
 	config.Kafka.AutoOffsetReset = viper.GetString("kafka.auto_offset_reset")
 	config.Kafka.EnableAutoCommit = viper.GetBool("kafka.enable_auto_commit")
+	config.Kafka.DeadLetter.Enabled = viper.GetBool("kafka.dead_letter.enabled")
+	config.Kafka.DeadLetter.TopicSuffix = viper.GetString("kafka.dead_letter.topic_suffix")
+	config.Kafka.DeadLetter.MaxAttempts = viper.GetInt("kafka.dead_letter.max_attempts")
+	config.Kafka.DeadLetter.RetryBackoffMs = viper.GetInt("kafka.dead_letter.retry_backoff_ms")
+
+	// Handle KAFKA_DLQ_TOPICS given as comma-separated source=target pairs
+	config.Kafka.DeadLetter.Topics = make(map[string]string)
+	if pairsStr := viper.GetString("kafka.dead_letter.topics"); pairsStr != "" {
+		for _, pair := range strings.Split(pairsStr, ",") {
+			source, target, found := strings.Cut(pair, "=")
+			if !found {
+				return nil, fmt.Errorf("invalid KAFKA_DLQ_TOPICS entry %q, expected source=target", pair)
+			}
+			config.Kafka.DeadLetter.Topics[strings.TrimSpace(source)] = strings.TrimSpace(target)
+		}
+	}
+
+	// Handle KAFKA_RETRY_TIERS given as comma-separated delays, e.g. 30s,5m
+	if tiersStr := viper.GetString("kafka.retry_tiers"); tiersStr != "" {
+		for _, label := range strings.Split(tiersStr, ",") {
+			label = strings.TrimSpace(label)
+			delay, err := time.ParseDuration(label)
+			if err != nil {
+				return nil, fmt.Errorf("invalid KAFKA_RETRY_TIERS entry %q: %w", label, err)
+			}
+			config.Kafka.RetryTiers = append(config.Kafka.RetryTiers, RetryTierConfig{Label: label, Delay: delay})
+		}
+	}
 	
 	config.FCM.CredentialsPath = viper.GetString("fcm.credentials_path")
 	config.FCM.ProjectID = viper.GetString("fcm.project_id")
@@ -148,10 +397,171 @@ func Load() (*Config, error) {
 	
 	config.Retry.MaxAttempts = viper.GetInt("retry.max_attempts")
 	config.Retry.DelaySeconds = viper.GetInt("retry.delay_seconds")
+	config.Retry.MaxDelaySeconds = viper.GetInt("retry.max_delay_seconds")
+	config.Retry.PollIntervalSeconds = viper.GetInt("retry.poll_interval_seconds")
+	config.Retry.BatchSize = viper.GetInt("retry.batch_size")
+
+	config.Reminder.Enabled = viper.GetBool("reminder.enabled")
+	config.Reminder.PollIntervalSeconds = viper.GetInt("reminder.poll_interval_seconds")
+	config.Reminder.BatchSize = viper.GetInt("reminder.batch_size")
+
+	config.Scheduler.PollIntervalSeconds = viper.GetInt("scheduler.poll_interval_seconds")
+	config.Scheduler.BatchSize = viper.GetInt("scheduler.batch_size")
+
+	config.Channels.Default = splitChannels(viper.GetString("channels.default"), ",")
+
+	// Handle NOTIFICATION_CHANNEL_ROUTES given as comma-separated
+	// type=channel+channel pairs, e.g. incoming_call=push,task_overdue=push+email
+	config.Channels.Routes = make(map[string][]string)
+	if routesStr := viper.GetString("channels.routes"); routesStr != "" {
+		for _, route := range strings.Split(routesStr, ",") {
+			notificationType, channels, found := strings.Cut(route, "=")
+			if !found {
+				return nil, fmt.Errorf("invalid NOTIFICATION_CHANNEL_ROUTES entry %q, expected type=channel+channel", route)
+			}
+			config.Channels.Routes[strings.TrimSpace(notificationType)] = splitChannels(channels, "+")
+		}
+	}
+
+	config.Email.Enabled = viper.GetBool("email.enabled")
+	config.Email.SMTPHost = viper.GetString("email.smtp_host")
+	config.Email.SMTPPort = viper.GetInt("email.smtp_port")
+	config.Email.Username = viper.GetString("email.username")
+	config.Email.Password = viper.GetString("email.password")
+	config.Email.FromAddress = viper.GetString("email.from_address")
+	config.Email.FromName = viper.GetString("email.from_name")
+	config.Email.TLSMode = strings.ToLower(viper.GetString("email.tls_mode"))
+	config.Email.TimeoutSeconds = viper.GetInt("email.timeout_seconds")
+
+	config.WebPush.Enabled = viper.GetBool("web_push.enabled")
+	config.WebPush.VAPIDPublicKey = viper.GetString("web_push.vapid_public_key")
+	config.WebPush.VAPIDPrivateKey = viper.GetString("web_push.vapid_private_key")
+	config.WebPush.Subject = viper.GetString("web_push.subject")
+	config.WebPush.TTLSeconds = viper.GetInt("web_push.ttl_seconds")
+	config.WebPush.TimeoutSeconds = viper.GetInt("web_push.timeout_seconds")
+	config.WebPush.AllowInsecureEndpoints = viper.GetBool("web_push.allow_insecure_endpoints")
+
+	config.Webhook.TimeoutSeconds = viper.GetInt("webhook.timeout_seconds")
+	config.Webhook.MaxAttempts = viper.GetInt("webhook.max_attempts")
+	config.Webhook.RetryDelaySeconds = viper.GetInt("webhook.retry_delay_seconds")
+	config.Webhook.RetryMaxDelaySeconds = viper.GetInt("webhook.retry_max_delay_seconds")
+	config.Webhook.DisableAfterFailures = viper.GetInt("webhook.disable_after_failures")
+	config.Webhook.PollIntervalSeconds = viper.GetInt("webhook.poll_interval_seconds")
+	config.Webhook.BatchSize = viper.GetInt("webhook.batch_size")
+
+	config.Realtime.Channel = viper.GetString("realtime.channel")
+	config.Realtime.ReplayLimit = viper.GetInt("realtime.replay_limit")
+	config.Realtime.HeartbeatSeconds = viper.GetInt("realtime.heartbeat_seconds")
+	config.Realtime.BufferSize = viper.GetInt("realtime.buffer_size")
+
+	config.Digest.Enabled = viper.GetBool("digest.enabled")
+	config.Digest.WindowMinutes = viper.GetInt("digest.window_minutes")
+	config.Digest.PollIntervalSeconds = viper.GetInt("digest.poll_interval_seconds")
+	config.Digest.BatchSize = viper.GetInt("digest.batch_size")
+
+	config.Coalesce.WindowSeconds = viper.GetInt("coalesce.window_seconds")
+
+	config.QuietHours.BypassPriority = viper.GetInt("quiet_hours.bypass_priority")
 
 	return &config, nil
 }
 
+// splitChannels splits a list of channel names, dropping empty entries
+func splitChannels(value string, sep string) []string {
+	var channels []string
+	for _, name := range strings.Split(value, sep) {
+		if name = strings.TrimSpace(name); name != "" {
+			channels = append(channels, name)
+		}
+	}
+	return channels
+}
+
+// RetryBackoff returns the delay between in-process handler attempts
+func (d *DeadLetterConfig) RetryBackoff() time.Duration {
+	return time.Duration(d.RetryBackoffMs) * time.Millisecond
+}
+
+// Delay returns the base delay before the first retry of a failed notification
+func (r *RetryConfig) Delay() time.Duration {
+	return time.Duration(r.DelaySeconds) * time.Second
+}
+
+// MaxDelay caps the exponential backoff between retries
+func (r *RetryConfig) MaxDelay() time.Duration {
+	return time.Duration(r.MaxDelaySeconds) * time.Second
+}
+
+// PollInterval returns how often the retry worker looks for due retries
+func (r *RetryConfig) PollInterval() time.Duration {
+	return time.Duration(r.PollIntervalSeconds) * time.Second
+}
+
+// PollInterval returns how often the reminder scheduler looks for due reminders
+func (r *ReminderConfig) PollInterval() time.Duration {
+	return time.Duration(r.PollIntervalSeconds) * time.Second
+}
+
+// PollInterval returns how often the scheduler looks for due notifications
+func (s *SchedulerConfig) PollInterval() time.Duration {
+	return time.Duration(s.PollIntervalSeconds) * time.Second
+}
+
+// Timeout returns how long one SMTP conversation may take
+func (e *EmailConfig) Timeout() time.Duration {
+	return time.Duration(e.TimeoutSeconds) * time.Second
+}
+
+// TTL returns how long push services keep a message for an offline browser
+func (w *WebPushConfig) TTL() time.Duration {
+	return time.Duration(w.TTLSeconds) * time.Second
+}
+
+// Timeout returns how long one push service request may take
+func (w *WebPushConfig) Timeout() time.Duration {
+	return time.Duration(w.TimeoutSeconds) * time.Second
+}
+
+// Timeout returns how long one webhook request may take
+func (w *WebhookConfig) Timeout() time.Duration {
+	return time.Duration(w.TimeoutSeconds) * time.Second
+}
+
+// RetryDelay returns the backoff before the first webhook retry
+func (w *WebhookConfig) RetryDelay() time.Duration {
+	return time.Duration(w.RetryDelaySeconds) * time.Second
+}
+
+// RetryMaxDelay caps the exponential webhook backoff
+func (w *WebhookConfig) RetryMaxDelay() time.Duration {
+	return time.Duration(w.RetryMaxDelaySeconds) * time.Second
+}
+
+// PollInterval returns how often the webhook worker looks for due deliveries
+func (w *WebhookConfig) PollInterval() time.Duration {
+	return time.Duration(w.PollIntervalSeconds) * time.Second
+}
+
+// Heartbeat returns how often idle streams are pinged
+func (r *RealtimeConfig) Heartbeat() time.Duration {
+	return time.Duration(r.HeartbeatSeconds) * time.Second
+}
+
+// Window returns how long a digest collects notifications before it is sent
+func (d *DigestConfig) Window() time.Duration {
+	return time.Duration(d.WindowMinutes) * time.Minute
+}
+
+// PollInterval returns how often the digest worker looks for due digests
+func (d *DigestConfig) PollInterval() time.Duration {
+	return time.Duration(d.PollIntervalSeconds) * time.Second
+}
+
+// Window returns how long updates of the same task are coalesced
+func (c *CoalesceConfig) Window() time.Duration {
+	return time.Duration(c.WindowSeconds) * time.Second
+}
+
 // GetDSN returns the PostgreSQL connection string
 func (c *DatabaseConfig) GetDSN() string {
 	return fmt.Sprintf(
@@ -3,6 +3,10 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/corechain/notification-service/pkg/constants"
 )
 
 func (c *Config) Validate() error {
@@ -22,6 +26,50 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("server config: %w", err)
 	}
 
+	if err := c.Retry.Validate(); err != nil {
+		return fmt.Errorf("retry config: %w", err)
+	}
+
+	if err := c.Reminder.Validate(); err != nil {
+		return fmt.Errorf("reminder config: %w", err)
+	}
+
+	if err := c.Scheduler.Validate(); err != nil {
+		return fmt.Errorf("scheduler config: %w", err)
+	}
+
+	if err := c.Channels.Validate(); err != nil {
+		return fmt.Errorf("channels config: %w", err)
+	}
+
+	if err := c.Email.Validate(); err != nil {
+		return fmt.Errorf("email config: %w", err)
+	}
+
+	if err := c.WebPush.Validate(); err != nil {
+		return fmt.Errorf("web push config: %w", err)
+	}
+
+	if err := c.Webhook.Validate(); err != nil {
+		return fmt.Errorf("webhook config: %w", err)
+	}
+
+	if err := c.Realtime.Validate(); err != nil {
+		return fmt.Errorf("realtime config: %w", err)
+	}
+
+	if err := c.Digest.Validate(); err != nil {
+		return fmt.Errorf("digest config: %w", err)
+	}
+
+	if err := c.Coalesce.Validate(); err != nil {
+		return fmt.Errorf("coalesce config: %w", err)
+	}
+
+	if err := c.QuietHours.Validate(); err != nil {
+		return fmt.Errorf("quiet hours config: %w", err)
+	}
+
 	return nil
 }
 
@@ -58,6 +106,53 @@ func (k *KafkaConfig) Validate() error {
 	if k.Topics.TaskCreated == "" {
 		return errors.New("task created topic is required")
 	}
+	if err := k.Topics.Validate(); err != nil {
+		return err
+	}
+	if err := k.DeadLetter.Validate(); err != nil {
+		return fmt.Errorf("dead letter: %w", err)
+	}
+	for i, tier := range k.RetryTiers {
+		if tier.Delay <= 0 {
+			return fmt.Errorf("retry tier %s must have a positive delay", tier.Label)
+		}
+		if i > 0 && tier.Delay <= k.RetryTiers[i-1].Delay {
+			return errors.New("retry tiers must be ordered by increasing delay")
+		}
+	}
+	return nil
+}
+
+// Validate ensures every configured topic serves exactly one event
+func (t *TopicsConfig) Validate() error {
+	seen := make(map[string]bool)
+	for _, topic := range []string{t.TaskCreated, t.TaskUpdated, t.TaskEvents, t.NewMessage, t.IncomingCall, t.Membership, t.DeviceTokenInvalidated} {
+		if topic == "" {
+			continue
+		}
+		if seen[topic] {
+			return fmt.Errorf("topic %s is configured for more than one event", topic)
+		}
+		seen[topic] = true
+	}
+	return nil
+}
+
+func (d *DeadLetterConfig) Validate() error {
+	if d.MaxAttempts <= 0 {
+		return errors.New("max attempts must be at least 1")
+	}
+	if d.RetryBackoffMs < 0 {
+		return errors.New("retry backoff must not be negative")
+	}
+	for source, target := range d.Topics {
+		if source == "" || target == "" {
+			return errors.New("dead letter topic mappings must name both source and target")
+		}
+		if source == target {
+			return fmt.Errorf("dead letter topic for %s must differ from the source topic", source)
+		}
+	}
 	return nil
 }
 
@@ -70,3 +165,179 @@ func (f *FCMConfig) Validate() error {
 	}
 	return nil
 }
+
+func (r *ReminderConfig) Validate() error {
+	if !r.Enabled {
+		return nil
+	}
+	if r.PollIntervalSeconds <= 0 {
+		return errors.New("reminder poll interval must be positive")
+	}
+	if r.BatchSize <= 0 {
+		return errors.New("reminder batch size must be positive")
+	}
+	return nil
+}
+
+func (s *SchedulerConfig) Validate() error {
+	if s.PollIntervalSeconds <= 0 {
+		return errors.New("scheduler poll interval must be positive")
+	}
+	if s.BatchSize <= 0 {
+		return errors.New("scheduler batch size must be positive")
+	}
+	return nil
+}
+
+func (r *RetryConfig) Validate() error {
+	if r.MaxAttempts < 0 {
+		return errors.New("max retry attempts must not be negative")
+	}
+	if r.DelaySeconds <= 0 {
+		return errors.New("retry delay must be positive")
+	}
+	if r.MaxDelaySeconds < r.DelaySeconds {
+		return errors.New("max retry delay must not be shorter than the retry delay")
+	}
+	if r.PollIntervalSeconds <= 0 {
+		return errors.New("retry poll interval must be positive")
+	}
+	if r.BatchSize <= 0 {
+		return errors.New("retry batch size must be positive")
+	}
+	return nil
+}
+
+// Validate checks the shape of the channel routes; channel names are checked
+// against the registered channels at startup
+func (c *ChannelsConfig) Validate() error {
+	if len(c.Default) == 0 {
+		return errors.New("at least one default channel is required")
+	}
+	for notificationType, channels := range c.Routes {
+		if notificationType == "" {
+			return errors.New("channel routes must name a notification type")
+		}
+		if len(channels) == 0 {
+			return fmt.Errorf("channel route for %s must name at least one channel", notificationType)
+		}
+	}
+	return nil
+}
+
+func (e *EmailConfig) Validate() error {
+	if !e.Enabled {
+		return nil
+	}
+	if e.SMTPHost == "" {
+		return errors.New("SMTP host is required")
+	}
+	if e.SMTPPort <= 0 || e.SMTPPort > 65535 {
+		return errors.New("invalid SMTP port")
+	}
+	if _, err := mail.ParseAddress(e.FromAddress); err != nil {
+		return fmt.Errorf("invalid sender address %q", e.FromAddress)
+	}
+	switch e.TLSMode {
+	case "none", "starttls", "tls":
+	default:
+		return fmt.Errorf("invalid SMTP TLS mode %q, expected none, starttls or tls", e.TLSMode)
+	}
+	if e.TimeoutSeconds <= 0 {
+		return errors.New("SMTP timeout must be positive")
+	}
+	return nil
+}
+
+// Validate checks that the VAPID settings are present; the keys themselves
+// are parsed when the client is created
+func (w *WebPushConfig) Validate() error {
+	if !w.Enabled {
+		return nil
+	}
+	if w.VAPIDPublicKey == "" || w.VAPIDPrivateKey == "" {
+		return errors.New("VAPID public and private keys are required")
+	}
+	if !strings.HasPrefix(w.Subject, "mailto:") && !strings.HasPrefix(w.Subject, "https://") {
+		return fmt.Errorf("VAPID subject %q must be a mailto: or https: URL", w.Subject)
+	}
+	if w.TTLSeconds < 0 {
+		return errors.New("web push TTL must not be negative")
+	}
+	if w.TimeoutSeconds <= 0 {
+		return errors.New("web push timeout must be positive")
+	}
+	return nil
+}
+
+func (w *WebhookConfig) Validate() error {
+	if w.TimeoutSeconds <= 0 {
+		return errors.New("webhook timeout must be positive")
+	}
+	if w.MaxAttempts < 0 {
+		return errors.New("max webhook attempts must not be negative")
+	}
+	if w.RetryDelaySeconds <= 0 {
+		return errors.New("webhook retry delay must be positive")
+	}
+	if w.RetryMaxDelaySeconds < w.RetryDelaySeconds {
+		return errors.New("max webhook retry delay must not be shorter than the retry delay")
+	}
+	if w.DisableAfterFailures <= 0 {
+		return errors.New("webhook disable threshold must be positive")
+	}
+	if w.PollIntervalSeconds <= 0 {
+		return errors.New("webhook poll interval must be positive")
+	}
+	if w.BatchSize <= 0 {
+		return errors.New("webhook batch size must be positive")
+	}
+	return nil
+}
+
+func (r *RealtimeConfig) Validate() error {
+	// Postgres truncates identifiers longer than 63 bytes
+	if r.Channel == "" || len(r.Channel) > 63 {
+		return errors.New("realtime channel must be 1 to 63 characters")
+	}
+	if r.ReplayLimit <= 0 {
+		return errors.New("realtime replay limit must be positive")
+	}
+	if r.HeartbeatSeconds <= 0 {
+		return errors.New("realtime heartbeat must be positive")
+	}
+	if r.BufferSize <= 0 {
+		return errors.New("realtime buffer size must be positive")
+	}
+	return nil
+}
+
+func (d *DigestConfig) Validate() error {
+	if !d.Enabled {
+		return nil
+	}
+	if d.WindowMinutes <= 0 {
+		return errors.New("digest window must be positive")
+	}
+	if d.PollIntervalSeconds <= 0 {
+		return errors.New("digest poll interval must be positive")
+	}
+	if d.BatchSize <= 0 {
+		return errors.New("digest batch size must be positive")
+	}
+	return nil
+}
+
+func (c *CoalesceConfig) Validate() error {
+	if c.WindowSeconds < 0 {
+		return errors.New("coalesce window must not be negative")
+	}
+	return nil
+}
+
+func (q *QuietHoursConfig) Validate() error {
+	if q.BypassPriority < constants.PriorityHigh || q.BypassPriority > constants.PriorityLow {
+		return fmt.Errorf("quiet hours bypass priority must be between %d and %d", constants.PriorityHigh, constants.PriorityLow)
+	}
+	return nil
+}
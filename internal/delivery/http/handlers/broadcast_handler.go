@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/corechain/notification-service/internal/application/dto"
+	"github.com/corechain/notification-service/internal/application/services"
+	"github.com/corechain/notification-service/internal/delivery/http/response"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type BroadcastHandler struct {
+	broadcastService *services.BroadcastService
+}
+
+func NewBroadcastHandler(broadcastService *services.BroadcastService) *BroadcastHandler {
+	return &BroadcastHandler{
+		broadcastService: broadcastService,
+	}
+}
+
+// maxIdempotencyKeyLength leaves room for the prefix within the 255
+// characters of the stored key
+const maxIdempotencyKeyLength = 200
+
+// SendBroadcast godoc
+// @Summary Broadcast a notification
+// @Description Send one notification to an FCM topic such as project_<ProjectID>, or to a condition expression. A request repeated with the same Idempotency-Key returns the broadcast stored the first time.
+// @Tags broadcasts
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Client key that makes retries of the request safe"
+// @Param broadcast body dto.BroadcastRequest true "Broadcast"
+// @Success 202 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/broadcasts [post]
+func (h *BroadcastHandler) SendBroadcast(c *gin.Context) {
+	var req dto.BroadcastRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, err.Error(), errors.ErrCodeInvalidPayload)
+		return
+	}
+
+	idempotencyKey := c.GetHeader("Idempotency-Key")
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		response.ErrorWithCode(c, http.StatusBadRequest, "Idempotency-Key must not exceed 200 characters", errors.ErrCodeInvalidPayload)
+		return
+	}
+
+	notification, err := h.broadcastService.Broadcast(c.Request.Context(), &req, idempotencyKey)
+	if err != nil {
+		if code := errors.CodeOf(err); code == errors.ErrCodeInvalidPayload {
+			response.ErrorWithCode(c, http.StatusBadRequest, err.Error(), code)
+			return
+		}
+		logger.Error("Failed to broadcast notification",
+			zap.Error(err),
+			zap.String("topic", req.Topic),
+			zap.String("condition", req.Condition),
+		)
+		response.Error(c, http.StatusInternalServerError, "Failed to broadcast notification")
+		return
+	}
+
+	response.JSONWithMessage(c, http.StatusAccepted, notification, "Broadcast accepted")
+}
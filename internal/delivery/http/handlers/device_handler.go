@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/corechain/notification-service/internal/application/dto"
+	"github.com/corechain/notification-service/internal/application/services"
+	"github.com/corechain/notification-service/internal/delivery/http/response"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type DeviceHandler struct {
+	deviceService *services.DeviceTokenService
+}
+
+func NewDeviceHandler(deviceService *services.DeviceTokenService) *DeviceHandler {
+	return &DeviceHandler{
+		deviceService: deviceService,
+	}
+}
+
+// GetUserDevices godoc
+// @Summary Get devices of a user
+// @Description Get every device registered for a user, most recently updated first
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/users/{userId}/devices [get]
+func (h *DeviceHandler) GetUserDevices(c *gin.Context) {
+	userID := c.Param("userId")
+	if userID == "" {
+		response.Error(c, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	devices, err := h.deviceService.GetUserDevices(c.Request.Context(), userID)
+	if err != nil {
+		logger.Error("Failed to get user devices",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve devices")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, gin.H{
+		"devices": devices,
+		"count":   len(devices),
+	})
+}
+
+// RegisterDevice godoc
+// @Summary Register a device
+// @Description Register a device of a user, or replace the token of a device that is already registered
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param device body dto.RegisterDeviceRequest true "Device"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/users/{userId}/devices [post]
+func (h *DeviceHandler) RegisterDevice(c *gin.Context) {
+	userID := c.Param("userId")
+	if userID == "" {
+		response.Error(c, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	var req dto.RegisterDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, err.Error(), errors.ErrCodeInvalidPayload)
+		return
+	}
+
+	device, err := h.deviceService.RegisterDevice(c.Request.Context(), userID, &req)
+	if err != nil {
+		logger.Error("Failed to register device",
+			zap.Error(err),
+			zap.String("user_id", userID),
+			zap.String("device_id", req.DeviceID),
+		)
+		response.Error(c, http.StatusInternalServerError, "Failed to register device")
+		return
+	}
+
+	response.JSONWithMessage(c, http.StatusOK, device, "Device registered")
+}
+
+// RefreshDevice godoc
+// @Summary Refresh a device token
+// @Description Replace the FCM token of a registered device after the app received a new one
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param deviceId path string true "Device ID"
+// @Param token body dto.RefreshDeviceRequest true "Token"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/users/{userId}/devices/{deviceId} [put]
+func (h *DeviceHandler) RefreshDevice(c *gin.Context) {
+	userID := c.Param("userId")
+	deviceID := c.Param("deviceId")
+	if userID == "" || deviceID == "" {
+		response.Error(c, http.StatusBadRequest, "User ID and device ID are required")
+		return
+	}
+
+	var req dto.RefreshDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, err.Error(), errors.ErrCodeInvalidPayload)
+		return
+	}
+
+	device, err := h.deviceService.RefreshDevice(c.Request.Context(), userID, deviceID, &req)
+	if err != nil {
+		if errors.CodeOf(err) == errors.ErrCodeNotFound {
+			response.Error(c, http.StatusNotFound, "Device not found")
+			return
+		}
+
+		logger.Error("Failed to refresh device token",
+			zap.Error(err),
+			zap.String("user_id", userID),
+			zap.String("device_id", deviceID),
+		)
+		response.Error(c, http.StatusInternalServerError, "Failed to refresh device token")
+		return
+	}
+
+	response.JSONWithMessage(c, http.StatusOK, device, "Device token refreshed")
+}
+
+// UnregisterDevice godoc
+// @Summary Unregister a device
+// @Description Remove a device and its token so it no longer receives notifications
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param deviceId path string true "Device ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/users/{userId}/devices/{deviceId} [delete]
+func (h *DeviceHandler) UnregisterDevice(c *gin.Context) {
+	userID := c.Param("userId")
+	deviceID := c.Param("deviceId")
+	if userID == "" || deviceID == "" {
+		response.Error(c, http.StatusBadRequest, "User ID and device ID are required")
+		return
+	}
+
+	if err := h.deviceService.UnregisterDevice(c.Request.Context(), userID, deviceID); err != nil {
+		if errors.CodeOf(err) == errors.ErrCodeNotFound {
+			response.Error(c, http.StatusNotFound, "Device not found")
+			return
+		}
+
+		logger.Error("Failed to unregister device",
+			zap.Error(err),
+			zap.String("user_id", userID),
+			zap.String("device_id", deviceID),
+		)
+		response.Error(c, http.StatusInternalServerError, "Failed to unregister device")
+		return
+	}
+
+	response.JSONWithMessage(c, http.StatusOK, gin.H{"device_id": deviceID}, "Device unregistered")
+}
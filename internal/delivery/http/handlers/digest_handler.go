@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/corechain/notification-service/internal/application/services"
+	"github.com/corechain/notification-service/internal/delivery/http/response"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type DigestHandler struct {
+	digestService *services.DigestService
+}
+
+func NewDigestHandler(digestService *services.DigestService) *DigestHandler {
+	return &DigestHandler{
+		digestService: digestService,
+	}
+}
+
+// GetDigest godoc
+// @Summary Get a notification digest
+// @Description Get a digest together with the notifications it collected, newest first
+// @Tags digests
+// @Accept json
+// @Produce json
+// @Param id path string true "Digest ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/digests/{id} [get]
+func (h *DigestHandler) GetDigest(c *gin.Context) {
+	id := c.Param("id")
+
+	digest, err := h.digestService.GetDigest(c.Request.Context(), id)
+	if err != nil {
+		if errors.CodeOf(err) == errors.ErrCodeNotFound {
+			response.Error(c, http.StatusNotFound, "Digest not found")
+			return
+		}
+
+		logger.Error("Failed to get notification digest",
+			zap.Error(err),
+			zap.String("digest_id", id),
+		)
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve digest")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, digest)
+}
@@ -3,9 +3,13 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/corechain/notification-service/internal/application/dto"
 	"github.com/corechain/notification-service/internal/application/services"
 	"github.com/corechain/notification-service/internal/delivery/http/response"
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/errors"
 	"github.com/corechain/notification-service/internal/utils/logger"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -23,13 +27,23 @@ func NewNotificationHandler(notificationService *services.NotificationService) *
 
 // GetUserNotifications godoc
 // @Summary Get notifications by user ID
-// @Description Get all notifications for a specific user with pagination
+// @Description Get the notifications of a user, newest first, filtered and paged with an opaque cursor
 // @Tags notifications
 // @Accept json
 // @Produce json
 // @Param userId path string true "User ID"
 // @Param limit query int false "Limit" default(20)
-// @Param offset query int false "Offset" default(0)
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param offset query int false "Offset, kept for older clients; prefer cursor" default(0)
+// @Param notification_type query string false "Notification type"
+// @Param status query string false "Comma-separated statuses"
+// @Param project_id query string false "Project ID"
+// @Param task_id query string false "Task ID"
+// @Param read query bool false "true for read, false for unread notifications"
+// @Param created_from query string false "RFC 3339 time, inclusive"
+// @Param created_to query string false "RFC 3339 time, exclusive"
+// @Param order query string false "desc (default) or asc by creation time"
+// @Param include_total query bool false "Also count every matching notification"
 // @Success 200 {object} response.SuccessResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
@@ -60,8 +74,38 @@ func (h *NotificationHandler) GetUserNotifications(c *gin.Context) {
 		}
 	}
 
-	notifications, err := h.notificationService.GetUserNotifications(c.Request.Context(), userID, limit, offset)
+	var req dto.ListNotificationsQuery
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, err.Error(), errors.ErrCodeInvalidPayload)
+		return
+	}
+
+	query := models.NotificationQuery{
+		Filter: models.NotificationFilter{
+			NotificationType: models.NotificationType(req.NotificationType),
+			ProjectID:        req.ProjectID,
+			TaskID:           req.TaskID,
+			Read:             req.Read,
+			CreatedFrom:      req.CreatedFrom,
+			CreatedTo:        req.CreatedTo,
+		},
+		Ascending: req.Order == "asc",
+		Limit:     limit,
+		Offset:    offset,
+	}
+	if req.Status != "" {
+		for _, status := range strings.Split(req.Status, ",") {
+			query.Filter.Statuses = append(query.Filter.Statuses, models.NotificationStatus(strings.TrimSpace(status)))
+		}
+	}
+
+	page, err := h.notificationService.ListUserNotifications(c.Request.Context(), userID, query, req.Cursor, req.IncludeTotal)
 	if err != nil {
+		if errors.CodeOf(err) == errors.ErrCodeInvalidPayload {
+			response.ErrorWithCode(c, http.StatusBadRequest, "Invalid cursor", errors.ErrCodeInvalidPayload)
+			return
+		}
+
 		logger.Error("Failed to get user notifications",
 			zap.Error(err),
 			zap.String("user_id", userID),
@@ -70,12 +114,18 @@ func (h *NotificationHandler) GetUserNotifications(c *gin.Context) {
 		return
 	}
 
-	response.JSON(c, http.StatusOK, gin.H{
-		"notifications": notifications,
-		"count":         len(notifications),
+	body := gin.H{
+		"notifications": page.Notifications,
+		"count":         len(page.Notifications),
 		"limit":         limit,
 		"offset":        offset,
-	})
+		"next_cursor":   page.NextCursor,
+	}
+	if page.Total != nil {
+		body["total"] = *page.Total
+	}
+
+	response.JSON(c, http.StatusOK, body)
 }
 
 // GetNotificationDetail godoc
@@ -100,7 +150,7 @@ func (h *NotificationHandler) GetNotificationDetail(c *gin.Context) {
 	notification, err := h.notificationService.GetNotificationByID(c.Request.Context(), id)
 	if err != nil {
 		// Check if it's a not found error
-		if err.Error() == "notification not found" {
+		if errors.CodeOf(err) == errors.ErrCodeNotFound {
 			response.Error(c, http.StatusNotFound, "Notification not found")
 			return
 		}
@@ -115,3 +165,224 @@ func (h *NotificationHandler) GetNotificationDetail(c *gin.Context) {
 
 	response.JSON(c, http.StatusOK, notification)
 }
+
+// CancelScheduledNotification godoc
+// @Summary Cancel a scheduled notification
+// @Description Cancel a notification that is still waiting for its send time
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param id path string true "Notification ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/notifications/detail/{id}/cancel [post]
+func (h *NotificationHandler) CancelScheduledNotification(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		response.Error(c, http.StatusBadRequest, "Notification ID is required")
+		return
+	}
+
+	if err := h.notificationService.CancelScheduledNotification(c.Request.Context(), id); err != nil {
+		switch errors.CodeOf(err) {
+		case errors.ErrCodeNotFound:
+			response.Error(c, http.StatusNotFound, "Notification not found")
+		case errors.ErrCodeConflict:
+			response.ErrorWithCode(c, http.StatusConflict, "Notification is not scheduled", errors.ErrCodeConflict)
+		default:
+			logger.Error("Failed to cancel scheduled notification",
+				zap.Error(err),
+				zap.String("notification_id", id),
+			)
+			response.Error(c, http.StatusInternalServerError, "Failed to cancel notification")
+		}
+		return
+	}
+
+	response.JSONWithMessage(c, http.StatusOK, gin.H{"id": id}, "Scheduled notification cancelled")
+}
+
+// GetNotificationAttempts godoc
+// @Summary Get delivery attempts of a notification
+// @Description Get every delivery attempt of a notification with provider, message ID, error code and latency
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param id path string true "Notification ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/notifications/detail/{id}/attempts [get]
+func (h *NotificationHandler) GetNotificationAttempts(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		response.Error(c, http.StatusBadRequest, "Notification ID is required")
+		return
+	}
+
+	attempts, err := h.notificationService.GetNotificationAttempts(c.Request.Context(), id)
+	if err != nil {
+		if errors.CodeOf(err) == errors.ErrCodeNotFound {
+			response.Error(c, http.StatusNotFound, "Notification not found")
+			return
+		}
+
+		logger.Error("Failed to get notification attempts",
+			zap.Error(err),
+			zap.String("notification_id", id),
+		)
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve notification attempts")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, gin.H{
+		"attempts": attempts,
+		"count":    len(attempts),
+	})
+}
+
+// MarkNotificationRead godoc
+// @Summary Mark a notification as read
+// @Description Mark a notification as read, and thereby as seen. Marking it again keeps the first read time.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param id path string true "Notification ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/notifications/{id}/read [patch]
+func (h *NotificationHandler) MarkNotificationRead(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		response.Error(c, http.StatusBadRequest, "Notification ID is required")
+		return
+	}
+
+	notification, err := h.notificationService.MarkRead(c.Request.Context(), id)
+	if err != nil {
+		if errors.CodeOf(err) == errors.ErrCodeNotFound {
+			response.Error(c, http.StatusNotFound, "Notification not found")
+			return
+		}
+
+		logger.Error("Failed to mark notification as read",
+			zap.Error(err),
+			zap.String("notification_id", id),
+		)
+		response.Error(c, http.StatusInternalServerError, "Failed to mark notification as read")
+		return
+	}
+
+	response.JSONWithMessage(c, http.StatusOK, notification, "Notification marked as read")
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary Mark all notifications of a user as read
+// @Description Mark every unread notification of a user as read, optionally only those of one type or project
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param notification_type query string false "Only notifications of this type"
+// @Param project_id query string false "Only notifications of this project"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/notifications/{userId}/read-all [post]
+func (h *NotificationHandler) MarkAllNotificationsRead(c *gin.Context) {
+	userID := c.Param("userId")
+	if userID == "" {
+		response.Error(c, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	updated, err := h.notificationService.MarkAllRead(c.Request.Context(), userID, readFilter(c))
+	if err != nil {
+		logger.Error("Failed to mark notifications as read",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		response.Error(c, http.StatusInternalServerError, "Failed to mark notifications as read")
+		return
+	}
+
+	response.JSONWithMessage(c, http.StatusOK, gin.H{"updated": updated}, "Notifications marked as read")
+}
+
+// MarkAllNotificationsSeen godoc
+// @Summary Mark all notifications of a user as seen
+// @Description Mark every unseen notification of a user as seen, e.g. when the notification list is opened. Accepts the same filters as read-all.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param notification_type query string false "Only notifications of this type"
+// @Param project_id query string false "Only notifications of this project"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/notifications/{userId}/seen-all [post]
+func (h *NotificationHandler) MarkAllNotificationsSeen(c *gin.Context) {
+	userID := c.Param("userId")
+	if userID == "" {
+		response.Error(c, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	updated, err := h.notificationService.MarkAllSeen(c.Request.Context(), userID, readFilter(c))
+	if err != nil {
+		logger.Error("Failed to mark notifications as seen",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		response.Error(c, http.StatusInternalServerError, "Failed to mark notifications as seen")
+		return
+	}
+
+	response.JSONWithMessage(c, http.StatusOK, gin.H{"updated": updated}, "Notifications marked as seen")
+}
+
+// GetUnreadCount godoc
+// @Summary Get the unread count of a user
+// @Description Get how many notifications of a user are unread and how many of those are unseen
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/notifications/{userId}/unread-count [get]
+func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
+	userID := c.Param("userId")
+	if userID == "" {
+		response.Error(c, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	count, err := h.notificationService.GetUnreadCount(c.Request.Context(), userID)
+	if err != nil {
+		logger.Error("Failed to count unread notifications",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		response.Error(c, http.StatusInternalServerError, "Failed to count unread notifications")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, count)
+}
+
+// readFilter reads the optional type and project filters of the bulk endpoints
+func readFilter(c *gin.Context) models.NotificationFilter {
+	return models.NotificationFilter{
+		NotificationType: models.NotificationType(c.Query("notification_type")),
+		ProjectID:        c.Query("project_id"),
+	}
+}
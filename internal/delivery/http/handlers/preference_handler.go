@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/corechain/notification-service/internal/application/dto"
+	"github.com/corechain/notification-service/internal/application/services"
+	"github.com/corechain/notification-service/internal/delivery/http/response"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type PreferenceHandler struct {
+	preferenceService *services.PreferenceService
+}
+
+func NewPreferenceHandler(preferenceService *services.PreferenceService) *PreferenceHandler {
+	return &PreferenceHandler{
+		preferenceService: preferenceService,
+	}
+}
+
+// GetPreferences godoc
+// @Summary Get notification preferences of a user
+// @Description Get the channels, mutes and per-type or per-project overrides of a user
+// @Tags preferences
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/users/{userId}/preferences [get]
+func (h *PreferenceHandler) GetPreferences(c *gin.Context) {
+	userID := c.Param("userId")
+	if userID == "" {
+		response.Error(c, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	preferences, err := h.preferenceService.GetPreferences(c.Request.Context(), userID)
+	if err != nil {
+		logger.Error("Failed to get notification preferences",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve notification preferences")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, preferences)
+}
+
+// UpdatePreferences godoc
+// @Summary Replace notification preferences of a user
+// @Description Replace the channels, mutes and overrides of a user; overrides left out are removed
+// @Tags preferences
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param preferences body dto.UpdatePreferencesRequest true "Preferences"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/users/{userId}/preferences [put]
+func (h *PreferenceHandler) UpdatePreferences(c *gin.Context) {
+	userID := c.Param("userId")
+	if userID == "" {
+		response.Error(c, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	var req dto.UpdatePreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, err.Error(), errors.ErrCodeInvalidPayload)
+		return
+	}
+
+	preferences, err := h.preferenceService.UpdatePreferences(c.Request.Context(), userID, &req)
+	if err != nil {
+		if errors.CodeOf(err) == errors.ErrCodeInvalidPayload {
+			response.ErrorWithCode(c, http.StatusBadRequest, err.Error(), errors.ErrCodeInvalidPayload)
+			return
+		}
+
+		logger.Error("Failed to update notification preferences",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		response.Error(c, http.StatusInternalServerError, "Failed to update notification preferences")
+		return
+	}
+
+	response.JSONWithMessage(c, http.StatusOK, preferences, "Notification preferences updated")
+}
+
+// SetDoNotDisturb godoc
+// @Summary Turn on do-not-disturb for a user
+// @Description Hold back notifications that aren't urgent until the given time; incoming calls and high-priority notifications still go out
+// @Tags preferences
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param request body dto.DoNotDisturbRequest true "Do-not-disturb end"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/users/{userId}/preferences/do-not-disturb [put]
+func (h *PreferenceHandler) SetDoNotDisturb(c *gin.Context) {
+	userID := c.Param("userId")
+	if userID == "" {
+		response.Error(c, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	var req dto.DoNotDisturbRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, err.Error(), errors.ErrCodeInvalidPayload)
+		return
+	}
+
+	if err := h.preferenceService.SetDoNotDisturb(c.Request.Context(), userID, req.Until); err != nil {
+		logger.Error("Failed to set do not disturb",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		response.Error(c, http.StatusInternalServerError, "Failed to set do not disturb")
+		return
+	}
+
+	response.JSONWithMessage(c, http.StatusOK, gin.H{"user_id": userID, "until": req.Until}, "Do not disturb set")
+}
+
+// ClearDoNotDisturb godoc
+// @Summary Turn off do-not-disturb for a user
+// @Description End do-not-disturb early; notifications held back are sent with the next scheduler run unless quiet hours still apply
+// @Tags preferences
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/users/{userId}/preferences/do-not-disturb [delete]
+func (h *PreferenceHandler) ClearDoNotDisturb(c *gin.Context) {
+	userID := c.Param("userId")
+	if userID == "" {
+		response.Error(c, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	if err := h.preferenceService.ClearDoNotDisturb(c.Request.Context(), userID); err != nil {
+		logger.Error("Failed to clear do not disturb",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		response.Error(c, http.StatusInternalServerError, "Failed to clear do not disturb")
+		return
+	}
+
+	response.JSONWithMessage(c, http.StatusOK, gin.H{"user_id": userID}, "Do not disturb cleared")
+}
+
+// ResetPreferences godoc
+// @Summary Reset notification preferences of a user
+// @Description Remove every preference of a user so notifications follow the default routing
+// @Tags preferences
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/users/{userId}/preferences [delete]
+func (h *PreferenceHandler) ResetPreferences(c *gin.Context) {
+	userID := c.Param("userId")
+	if userID == "" {
+		response.Error(c, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	if err := h.preferenceService.ResetPreferences(c.Request.Context(), userID); err != nil {
+		logger.Error("Failed to reset notification preferences",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		response.Error(c, http.StatusInternalServerError, "Failed to reset notification preferences")
+		return
+	}
+
+	response.JSONWithMessage(c, http.StatusOK, gin.H{"user_id": userID}, "Notification preferences reset")
+}
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/corechain/notification-service/internal/application/dto"
+	"github.com/corechain/notification-service/internal/application/services"
+	"github.com/corechain/notification-service/internal/delivery/http/response"
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+// streamEvent names the event carrying a notification on both transports
+const streamEvent = "notification"
+
+// wsWriteTimeout bounds a single WebSocket write
+const wsWriteTimeout = 10 * time.Second
+
+type StreamHandler struct {
+	realtimeService *services.RealtimeService
+	heartbeat       time.Duration
+	upgrader        websocket.Upgrader
+}
+
+func NewStreamHandler(realtimeService *services.RealtimeService, heartbeat time.Duration) *StreamHandler {
+	return &StreamHandler{
+		realtimeService: realtimeService,
+		heartbeat:       heartbeat,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			// Origins are not restricted, matching the CORS policy
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// StreamSSE godoc
+// @Summary Stream notifications over Server-Sent Events
+// @Description Push each new notification of a user as it is created. Reconnecting clients send Last-Event-ID to receive what they missed.
+// @Tags notifications
+// @Produce text/event-stream
+// @Param userId path string true "User ID"
+// @Param Last-Event-ID header string false "ID of the last notification received"
+// @Param last_event_id query string false "Same as Last-Event-ID, for clients that cannot set headers"
+// @Success 200 {string} string "event stream"
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/notifications/{userId}/stream [get]
+func (h *StreamHandler) StreamSSE(c *gin.Context) {
+	userID := c.Param("userId")
+	if userID == "" {
+		response.Error(c, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	subscription, missed, err := h.realtimeService.Open(c.Request.Context(), userID, lastEventID)
+	if err != nil {
+		logger.Error("Failed to open notification stream",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		response.Error(c, http.StatusInternalServerError, "Failed to open notification stream")
+		return
+	}
+	defer h.realtimeService.Unsubscribe(subscription)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	write := func(notification *models.Notification) error {
+		data, err := json.Marshal(notification)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", notification.ID, streamEvent, data); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	}
+
+	h.pump(c.Request.Context().Done(), subscription, missed, write, func() error {
+		if _, err := fmt.Fprint(c.Writer, ": ping\n\n"); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	})
+}
+
+// StreamWebSocket godoc
+// @Summary Stream notifications over WebSocket
+// @Description Push each new notification of a user as a JSON frame {id, event, data}. Reconnecting clients pass last_event_id to receive what they missed.
+// @Tags notifications
+// @Param userId path string true "User ID"
+// @Param last_event_id query string false "ID of the last notification received"
+// @Success 101 {string} string "switching protocols"
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/notifications/{userId}/ws [get]
+func (h *StreamHandler) StreamWebSocket(c *gin.Context) {
+	userID := c.Param("userId")
+	if userID == "" {
+		response.Error(c, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	subscription, missed, err := h.realtimeService.Open(c.Request.Context(), userID, c.Query("last_event_id"))
+	if err != nil {
+		logger.Error("Failed to open notification stream",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		response.Error(c, http.StatusInternalServerError, "Failed to open notification stream")
+		return
+	}
+	defer h.realtimeService.Unsubscribe(subscription)
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader has already written the error response
+		logger.Warn("WebSocket upgrade failed", zap.Error(err), zap.String("user_id", userID))
+		return
+	}
+	defer conn.Close()
+
+	// Clients only send control frames; reading processes them and notices
+	// when the connection goes away
+	closed := make(chan struct{})
+	conn.SetReadDeadline(time.Now().Add(2 * h.heartbeat))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * h.heartbeat))
+	})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	write := func(notification *models.Notification) error {
+		conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+		return conn.WriteJSON(dto.RealtimeMessage{ID: notification.ID, Event: streamEvent, Data: notification})
+	}
+
+	h.pump(closed, subscription, missed, write, func() error {
+		return conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout))
+	})
+
+	conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseGoingAway, ""),
+		time.Now().Add(wsWriteTimeout))
+}
+
+// pump writes the missed notifications and then the live ones until the
+// client leaves, the subscription is dropped or a write fails
+func (h *StreamHandler) pump(stop <-chan struct{}, subscription *services.RealtimeSubscription, missed []*models.Notification, write func(*models.Notification) error, ping func() error) {
+	replayed := make(map[string]bool, len(missed))
+	for _, notification := range missed {
+		if err := write(notification); err != nil {
+			return
+		}
+		replayed[notification.ID] = true
+	}
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-subscription.Done():
+			return
+		case notification := <-subscription.Events():
+			if replayed[notification.ID] {
+				continue
+			}
+			if err := write(notification); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := ping(); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/corechain/notification-service/internal/application/dto"
+	"github.com/corechain/notification-service/internal/application/services"
+	"github.com/corechain/notification-service/internal/delivery/http/response"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type WebPushHandler struct {
+	webPushService *services.WebPushService
+}
+
+func NewWebPushHandler(webPushService *services.WebPushService) *WebPushHandler {
+	return &WebPushHandler{
+		webPushService: webPushService,
+	}
+}
+
+// GetVAPIDPublicKey godoc
+// @Summary Get the VAPID public key
+// @Description Get the application server key browsers pass to pushManager.subscribe
+// @Tags web-push
+// @Produce json
+// @Success 200 {object} response.SuccessResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Router /api/v1/web-push/public-key [get]
+func (h *WebPushHandler) GetVAPIDPublicKey(c *gin.Context) {
+	publicKey := h.webPushService.PublicKey()
+	if publicKey == "" {
+		response.Error(c, http.StatusNotFound, "Web push is not enabled")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, gin.H{"public_key": publicKey})
+}
+
+// GetUserWebPushSubscriptions godoc
+// @Summary Get web push subscriptions of a user
+// @Description Get every browser subscribed for a user, most recently updated first
+// @Tags web-push
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/users/{userId}/web-push-subscriptions [get]
+func (h *WebPushHandler) GetUserWebPushSubscriptions(c *gin.Context) {
+	userID := c.Param("userId")
+	if userID == "" {
+		response.Error(c, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	subscriptions, err := h.webPushService.GetUserSubscriptions(c.Request.Context(), userID)
+	if err != nil {
+		logger.Error("Failed to get web push subscriptions",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve web push subscriptions")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, gin.H{
+		"subscriptions": subscriptions,
+		"count":         len(subscriptions),
+	})
+}
+
+// RegisterWebPushSubscription godoc
+// @Summary Register a web push subscription
+// @Description Store the PushSubscription of a browser, or refresh the keys of a known endpoint
+// @Tags web-push
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param subscription body dto.RegisterWebPushSubscriptionRequest true "Subscription"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/users/{userId}/web-push-subscriptions [post]
+func (h *WebPushHandler) RegisterWebPushSubscription(c *gin.Context) {
+	userID := c.Param("userId")
+	if userID == "" {
+		response.Error(c, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	var req dto.RegisterWebPushSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, err.Error(), errors.ErrCodeInvalidPayload)
+		return
+	}
+
+	subscription, err := h.webPushService.Subscribe(c.Request.Context(), userID, &req)
+	if err != nil {
+		if errors.CodeOf(err) == errors.ErrCodeInvalidPayload {
+			response.ErrorWithCode(c, http.StatusBadRequest, err.Error(), errors.ErrCodeInvalidPayload)
+			return
+		}
+
+		logger.Error("Failed to register web push subscription",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		response.Error(c, http.StatusInternalServerError, "Failed to register web push subscription")
+		return
+	}
+
+	response.JSONWithMessage(c, http.StatusOK, subscription, "Web push subscription registered")
+}
+
+// DeleteWebPushSubscription godoc
+// @Summary Delete a web push subscription
+// @Description Remove a browser subscription, e.g. after the user turned notifications off
+// @Tags web-push
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param subscriptionId path string true "Subscription ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/users/{userId}/web-push-subscriptions/{subscriptionId} [delete]
+func (h *WebPushHandler) DeleteWebPushSubscription(c *gin.Context) {
+	userID := c.Param("userId")
+	subscriptionID := c.Param("subscriptionId")
+	if userID == "" || subscriptionID == "" {
+		response.Error(c, http.StatusBadRequest, "User ID and subscription ID are required")
+		return
+	}
+
+	if err := h.webPushService.Unsubscribe(c.Request.Context(), userID, subscriptionID); err != nil {
+		if errors.CodeOf(err) == errors.ErrCodeNotFound {
+			response.Error(c, http.StatusNotFound, "Web push subscription not found")
+			return
+		}
+
+		logger.Error("Failed to delete web push subscription",
+			zap.Error(err),
+			zap.String("user_id", userID),
+			zap.String("subscription_id", subscriptionID),
+		)
+		response.Error(c, http.StatusInternalServerError, "Failed to delete web push subscription")
+		return
+	}
+
+	response.JSONWithMessage(c, http.StatusOK, gin.H{"subscription_id": subscriptionID}, "Web push subscription deleted")
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/corechain/notification-service/internal/application/dto"
+	"github.com/corechain/notification-service/internal/application/services"
+	"github.com/corechain/notification-service/internal/delivery/http/response"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type WebhookHandler struct {
+	webhookService *services.WebhookService
+}
+
+func NewWebhookHandler(webhookService *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// CreateWebhook godoc
+// @Summary Register a webhook
+// @Description Register an endpoint that receives a signed JSON copy of every sent notification matching its project and notification type. The secret is only returned here.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body dto.CreateWebhookRequest true "Webhook"
+// @Success 201 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req dto.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, err.Error(), errors.ErrCodeInvalidPayload)
+		return
+	}
+
+	subscription, err := h.webhookService.CreateSubscription(c.Request.Context(), &req)
+	if err != nil {
+		if code := errors.CodeOf(err); code == errors.ErrCodeInvalidPayload {
+			response.ErrorWithCode(c, http.StatusBadRequest, err.Error(), code)
+			return
+		}
+
+		logger.Error("Failed to create webhook", zap.Error(err))
+		response.Error(c, http.StatusInternalServerError, "Failed to create webhook")
+		return
+	}
+
+	response.JSONWithMessage(c, http.StatusCreated, subscription, "Webhook created")
+}
+
+// ListWebhooks godoc
+// @Summary List webhooks
+// @Description List webhook subscriptions, optionally only those of one project
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param project_id query string false "Project ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/webhooks [get]
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	projectID := c.Query("project_id")
+
+	subscriptions, err := h.webhookService.ListSubscriptions(c.Request.Context(), projectID)
+	if err != nil {
+		logger.Error("Failed to list webhooks",
+			zap.Error(err),
+			zap.String("project_id", projectID),
+		)
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve webhooks")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, gin.H{
+		"webhooks": subscriptions,
+		"count":    len(subscriptions),
+	})
+}
+
+// GetWebhook godoc
+// @Summary Get a webhook
+// @Description Get a webhook subscription with its failure count and disabled state
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	id := c.Param("id")
+
+	subscription, err := h.webhookService.GetSubscription(c.Request.Context(), id)
+	if err != nil {
+		h.handleLookupError(c, err, id, "Failed to retrieve webhook")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, subscription)
+}
+
+// UpdateWebhook godoc
+// @Summary Update a webhook
+// @Description Change the fields that are set. Setting is_active to true re-enables a webhook that was disabled after repeated failures.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param webhook body dto.UpdateWebhookRequest true "Changes"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/webhooks/{id} [put]
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorWithCode(c, http.StatusBadRequest, err.Error(), errors.ErrCodeInvalidPayload)
+		return
+	}
+
+	subscription, err := h.webhookService.UpdateSubscription(c.Request.Context(), id, &req)
+	if err != nil {
+		if code := errors.CodeOf(err); code == errors.ErrCodeInvalidPayload {
+			response.ErrorWithCode(c, http.StatusBadRequest, err.Error(), code)
+			return
+		}
+		h.handleLookupError(c, err, id, "Failed to update webhook")
+		return
+	}
+
+	response.JSONWithMessage(c, http.StatusOK, subscription, "Webhook updated")
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook
+// @Description Delete a webhook subscription and its pending deliveries
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.webhookService.DeleteSubscription(c.Request.Context(), id); err != nil {
+		h.handleLookupError(c, err, id, "Failed to delete webhook")
+		return
+	}
+
+	response.JSONWithMessage(c, http.StatusOK, gin.H{"id": id}, "Webhook deleted")
+}
+
+// GetWebhookDeliveries godoc
+// @Summary Get deliveries of a webhook
+// @Description Get the latest deliveries of a webhook with their status, attempt count and last error
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param limit query int false "Limit" default(50)
+// @Success 200 {object} response.SuccessResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	id := c.Param("id")
+
+	limit := 50
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+			if limit > 200 {
+				limit = 200
+			}
+		}
+	}
+
+	deliveries, err := h.webhookService.GetDeliveries(c.Request.Context(), id, limit)
+	if err != nil {
+		h.handleLookupError(c, err, id, "Failed to retrieve webhook deliveries")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, gin.H{
+		"deliveries": deliveries,
+		"count":      len(deliveries),
+	})
+}
+
+func (h *WebhookHandler) handleLookupError(c *gin.Context, err error, id string, message string) {
+	if errors.CodeOf(err) == errors.ErrCodeNotFound {
+		response.Error(c, http.StatusNotFound, "Webhook not found")
+		return
+	}
+
+	logger.Error(message,
+		zap.Error(err),
+		zap.String("webhook_id", id),
+	)
+	response.Error(c, http.StatusInternalServerError, message)
+}
@@ -18,11 +18,25 @@ type Server struct {
 	router              *gin.Engine
 	httpServer          *http.Server
 	notificationHandler *handlers.NotificationHandler
+	deviceHandler       *handlers.DeviceHandler
+	broadcastHandler    *handlers.BroadcastHandler
+	webhookHandler      *handlers.WebhookHandler
+	streamHandler       *handlers.StreamHandler
+	webPushHandler      *handlers.WebPushHandler
+	preferenceHandler   *handlers.PreferenceHandler
+	digestHandler       *handlers.DigestHandler
 }
 
 type ServerConfig struct {
 	Port                int
 	NotificationHandler *handlers.NotificationHandler
+	DeviceHandler       *handlers.DeviceHandler
+	BroadcastHandler    *handlers.BroadcastHandler
+	WebhookHandler      *handlers.WebhookHandler
+	StreamHandler       *handlers.StreamHandler
+	WebPushHandler      *handlers.WebPushHandler
+	PreferenceHandler   *handlers.PreferenceHandler
+	DigestHandler       *handlers.DigestHandler
 }
 
 func NewServer(config ServerConfig) *Server {
@@ -49,6 +63,13 @@ func NewServer(config ServerConfig) *Server {
 	server := &Server{
 		router:              router,
 		notificationHandler: config.NotificationHandler,
+		deviceHandler:       config.DeviceHandler,
+		broadcastHandler:    config.BroadcastHandler,
+		webhookHandler:      config.WebhookHandler,
+		streamHandler:       config.StreamHandler,
+		webPushHandler:      config.WebPushHandler,
+		preferenceHandler:   config.PreferenceHandler,
+		digestHandler:       config.DigestHandler,
 	}
 
 	server.setupRoutes()
@@ -72,7 +93,52 @@ func (s *Server) setupRoutes() {
 		notifications := v1.Group("/notifications")
 		{
 			notifications.GET("/:userId", s.notificationHandler.GetUserNotifications)
+			notifications.GET("/:userId/stream", s.streamHandler.StreamSSE)
+			notifications.GET("/:userId/ws", s.streamHandler.StreamWebSocket)
+			notifications.GET("/:userId/unread-count", s.notificationHandler.GetUnreadCount)
+			notifications.POST("/:userId/read-all", s.notificationHandler.MarkAllNotificationsRead)
+			notifications.POST("/:userId/seen-all", s.notificationHandler.MarkAllNotificationsSeen)
+			notifications.PATCH("/:id/read", s.notificationHandler.MarkNotificationRead)
 			notifications.GET("/detail/:id", s.notificationHandler.GetNotificationDetail)
+			notifications.GET("/detail/:id/attempts", s.notificationHandler.GetNotificationAttempts)
+			notifications.POST("/detail/:id/cancel", s.notificationHandler.CancelScheduledNotification)
+		}
+
+		// Per-user devices, browser subscriptions and preferences
+		users := v1.Group("/users")
+		{
+			users.GET("/:userId/devices", s.deviceHandler.GetUserDevices)
+			users.POST("/:userId/devices", s.deviceHandler.RegisterDevice)
+			users.PUT("/:userId/devices/:deviceId", s.deviceHandler.RefreshDevice)
+			users.DELETE("/:userId/devices/:deviceId", s.deviceHandler.UnregisterDevice)
+			users.GET("/:userId/web-push-subscriptions", s.webPushHandler.GetUserWebPushSubscriptions)
+			users.POST("/:userId/web-push-subscriptions", s.webPushHandler.RegisterWebPushSubscription)
+			users.DELETE("/:userId/web-push-subscriptions/:subscriptionId", s.webPushHandler.DeleteWebPushSubscription)
+			users.GET("/:userId/preferences", s.preferenceHandler.GetPreferences)
+			users.PUT("/:userId/preferences", s.preferenceHandler.UpdatePreferences)
+			users.DELETE("/:userId/preferences", s.preferenceHandler.ResetPreferences)
+			users.PUT("/:userId/preferences/do-not-disturb", s.preferenceHandler.SetDoNotDisturb)
+			users.DELETE("/:userId/preferences/do-not-disturb", s.preferenceHandler.ClearDoNotDisturb)
+		}
+
+		// Key browsers subscribe to Web Push with
+		v1.GET("/web-push/public-key", s.webPushHandler.GetVAPIDPublicKey)
+
+		// Summaries of collected low-priority notifications
+		v1.GET("/digests/:id", s.digestHandler.GetDigest)
+
+		// Topic and condition broadcasts
+		v1.POST("/broadcasts", s.broadcastHandler.SendBroadcast)
+
+		// Outbound webhook subscriptions
+		webhooks := v1.Group("/webhooks")
+		{
+			webhooks.POST("", s.webhookHandler.CreateWebhook)
+			webhooks.GET("", s.webhookHandler.ListWebhooks)
+			webhooks.GET("/:id", s.webhookHandler.GetWebhook)
+			webhooks.PUT("/:id", s.webhookHandler.UpdateWebhook)
+			webhooks.DELETE("/:id", s.webhookHandler.DeleteWebhook)
+			webhooks.GET("/:id/deliveries", s.webhookHandler.GetWebhookDeliveries)
 		}
 	}
 }
@@ -0,0 +1,84 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/corechain/notification-service/internal/application/dto"
+	"github.com/corechain/notification-service/internal/application/services"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+type CallHandler struct {
+	callNotificationService *services.CallNotificationService
+}
+
+func NewCallHandler(callNotificationService *services.CallNotificationService) *CallHandler {
+	return &CallHandler{
+		callNotificationService: callNotificationService,
+	}
+}
+
+func (h *CallHandler) HandleIncomingCall(ctx context.Context, message []byte) error {
+	logger.Debug("Processing call.incoming event", zap.Int("message_size", len(message)))
+
+	var event dto.IncomingCallEvent
+	if err := json.Unmarshal(message, &event); err != nil {
+		logger.Error("Failed to unmarshal call.incoming event",
+			zap.Error(err),
+			zap.ByteString("message", message),
+		)
+		return errors.NewInvalidPayloadError("failed to unmarshal call.incoming event", err)
+	}
+
+	logger.Info("Received call.incoming event",
+		zap.String("call_id", event.Data.ID),
+		zap.String("caller_id", event.Data.CallerID),
+		zap.String("recipient_id", event.RecipientID()),
+	)
+
+	if err := h.validateIncomingCallEvent(&event); err != nil {
+		logger.Error("Invalid call.incoming event", zap.Error(err))
+		return err
+	}
+
+	if event.RecipientID() == event.Data.CallerID {
+		logger.Debug("Skipping call.incoming notification, caller called themselves",
+			zap.String("call_id", event.Data.ID),
+			zap.String("user_id", event.Data.CallerID),
+		)
+		return nil
+	}
+
+	if err := h.callNotificationService.ProcessIncomingCallEvent(ctx, &event); err != nil {
+		logger.Error("Failed to process call.incoming event",
+			zap.Error(err),
+			zap.String("call_id", event.Data.ID),
+		)
+		return err
+	}
+
+	logger.Info("Successfully processed call.incoming event",
+		zap.String("call_id", event.Data.ID),
+	)
+
+	return nil
+}
+
+func (h *CallHandler) validateIncomingCallEvent(event *dto.IncomingCallEvent) error {
+	if event.Data.ID == "" {
+		return errors.NewInvalidPayloadError("call ID is required", nil)
+	}
+
+	if event.Data.CallerID == "" {
+		return errors.NewInvalidPayloadError("caller ID is required", nil)
+	}
+
+	if event.RecipientID() == "" {
+		return errors.NewInvalidPayloadError("recipient user ID is required", nil)
+	}
+
+	return validateSendAt(event.SendAt, event.Timestamp)
+}
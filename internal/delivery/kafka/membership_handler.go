@@ -0,0 +1,86 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/corechain/notification-service/internal/application/dto"
+	"github.com/corechain/notification-service/internal/application/services"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"github.com/corechain/notification-service/pkg/constants"
+	"go.uber.org/zap"
+)
+
+type MembershipHandler struct {
+	topicSubscriptionService *services.TopicSubscriptionService
+}
+
+func NewMembershipHandler(topicSubscriptionService *services.TopicSubscriptionService) *MembershipHandler {
+	return &MembershipHandler{
+		topicSubscriptionService: topicSubscriptionService,
+	}
+}
+
+// HandleMembershipEvent keeps FCM topic subscriptions in line with project
+// and company membership events
+func (h *MembershipHandler) HandleMembershipEvent(ctx context.Context, message []byte) error {
+	logger.Debug("Processing membership event", zap.Int("message_size", len(message)))
+
+	var event dto.MembershipEvent
+	if err := json.Unmarshal(message, &event); err != nil {
+		logger.Error("Failed to unmarshal membership event",
+			zap.Error(err),
+			zap.ByteString("message", message),
+		)
+		return errors.NewInvalidPayloadError("failed to unmarshal membership event", err)
+	}
+
+	logger.Info("Received membership event",
+		zap.String("event_type", event.EventType),
+		zap.String("user_id", event.Data.UserID),
+		zap.String("topic", event.Topic()),
+	)
+
+	if err := h.validateMembershipEvent(&event); err != nil {
+		logger.Error("Invalid membership event", zap.Error(err))
+		return err
+	}
+
+	if err := h.topicSubscriptionService.ProcessMembershipEvent(ctx, &event); err != nil {
+		logger.Error("Failed to process membership event",
+			zap.Error(err),
+			zap.String("event_type", event.EventType),
+			zap.String("user_id", event.Data.UserID),
+		)
+		return err
+	}
+
+	logger.Info("Successfully processed membership event",
+		zap.String("event_type", event.EventType),
+		zap.String("user_id", event.Data.UserID),
+	)
+
+	return nil
+}
+
+func (h *MembershipHandler) validateMembershipEvent(event *dto.MembershipEvent) error {
+	switch event.EventType {
+	case constants.EventTypeProjectMemberAdded, constants.EventTypeProjectMemberRemoved:
+		if event.Data.ProjectID == "" {
+			return errors.NewInvalidPayloadError("project ID is required", nil)
+		}
+	case constants.EventTypeCompanyMemberAdded, constants.EventTypeCompanyMemberRemoved:
+		if event.Data.CompanyID == "" {
+			return errors.NewInvalidPayloadError("company ID is required", nil)
+		}
+	default:
+		return errors.NewInvalidPayloadError("unsupported membership event type: "+event.EventType, nil)
+	}
+
+	if event.Data.UserID == "" {
+		return errors.NewInvalidPayloadError("user ID is required", nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/corechain/notification-service/internal/application/dto"
+	"github.com/corechain/notification-service/internal/application/services"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+type MessageHandler struct {
+	messageNotificationService *services.MessageNotificationService
+}
+
+func NewMessageHandler(messageNotificationService *services.MessageNotificationService) *MessageHandler {
+	return &MessageHandler{
+		messageNotificationService: messageNotificationService,
+	}
+}
+
+func (h *MessageHandler) HandleNewMessage(ctx context.Context, message []byte) error {
+	logger.Debug("Processing message.new event", zap.Int("message_size", len(message)))
+
+	var event dto.MessageCreatedEvent
+	if err := json.Unmarshal(message, &event); err != nil {
+		logger.Error("Failed to unmarshal message.new event",
+			zap.Error(err),
+			zap.ByteString("message", message),
+		)
+		return errors.NewInvalidPayloadError("failed to unmarshal message.new event", err)
+	}
+
+	logger.Info("Received message.new event",
+		zap.String("message_id", event.Data.ID),
+		zap.String("sender_id", event.Data.SenderID),
+		zap.String("recipient_id", event.RecipientID()),
+	)
+
+	if err := h.validateMessageCreatedEvent(&event); err != nil {
+		logger.Error("Invalid message.new event", zap.Error(err))
+		return err
+	}
+
+	if event.RecipientID() == event.Data.SenderID {
+		logger.Debug("Skipping message.new notification, sender wrote to themselves",
+			zap.String("message_id", event.Data.ID),
+			zap.String("user_id", event.Data.SenderID),
+		)
+		return nil
+	}
+
+	if err := h.messageNotificationService.ProcessMessageCreatedEvent(ctx, &event); err != nil {
+		logger.Error("Failed to process message.new event",
+			zap.Error(err),
+			zap.String("message_id", event.Data.ID),
+		)
+		return err
+	}
+
+	logger.Info("Successfully processed message.new event",
+		zap.String("message_id", event.Data.ID),
+	)
+
+	return nil
+}
+
+func (h *MessageHandler) validateMessageCreatedEvent(event *dto.MessageCreatedEvent) error {
+	if event.Data.ID == "" {
+		return errors.NewInvalidPayloadError("message ID is required", nil)
+	}
+
+	if event.Data.SenderID == "" {
+		return errors.NewInvalidPayloadError("sender ID is required", nil)
+	}
+
+	if event.RecipientID() == "" {
+		return errors.NewInvalidPayloadError("recipient user ID is required", nil)
+	}
+
+	return validateSendAt(event.SendAt, event.Timestamp)
+}
@@ -3,11 +3,13 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/corechain/notification-service/internal/application/dto"
 	"github.com/corechain/notification-service/internal/application/services"
 	"github.com/corechain/notification-service/internal/utils/errors"
 	"github.com/corechain/notification-service/internal/utils/logger"
+	"github.com/corechain/notification-service/pkg/constants"
 	"go.uber.org/zap"
 )
 
@@ -62,7 +64,7 @@ func (h *TaskHandler) HandleTaskCreated(ctx context.Context, message []byte) err
 func (h *TaskHandler) HandleTaskUpdated(ctx context.Context, message []byte) error {
 	logger.Debug("Processing task.updated event", zap.Int("message_size", len(message)))
 
-	var event dto.TaskCreatedEvent
+	var event dto.TaskUpdatedEvent
 	if err := json.Unmarshal(message, &event); err != nil {
 		logger.Error("Failed to unmarshal task.updated event",
 			zap.Error(err),
@@ -73,9 +75,16 @@ func (h *TaskHandler) HandleTaskUpdated(ctx context.Context, message []byte) err
 
 	logger.Info("Received task.updated event",
 		zap.String("task_id", event.Data.ID),
-		zap.String("title", event.Data.Title),
+		zap.String("assigned_to", event.Metadata.AssignedToUser.ID),
+		zap.String("updated_by", event.UpdatedByID()),
+		zap.Strings("changed_fields", event.ChangedFields()),
 	)
 
+	if err := h.validateTaskUpdatedEvent(&event); err != nil {
+		logger.Error("Invalid task.updated event", zap.Error(err))
+		return err
+	}
+
 	if err := h.taskNotificationService.ProcessTaskUpdatedEvent(ctx, &event); err != nil {
 		logger.Error("Failed to process task.updated event",
 			zap.Error(err),
@@ -91,6 +100,88 @@ func (h *TaskHandler) HandleTaskUpdated(ctx context.Context, message []byte) err
 	return nil
 }
 
+// HandleTaskEvent consumes the shared task topic and dispatches each event by
+// its event_type. Unknown event types are skipped so the backend can publish
+// new ones before this service learns about them.
+func (h *TaskHandler) HandleTaskEvent(ctx context.Context, message []byte) error {
+	var envelope dto.TaskEventEnvelope
+	if err := json.Unmarshal(message, &envelope); err != nil {
+		logger.Error("Failed to unmarshal task event",
+			zap.Error(err),
+			zap.ByteString("message", message),
+		)
+		return errors.NewInvalidPayloadError("failed to unmarshal task event", err)
+	}
+
+	switch envelope.EventType {
+	case constants.EventTypeTaskCreated:
+		return h.HandleTaskCreated(ctx, message)
+	case constants.EventTypeTaskUpdated:
+		return h.HandleTaskUpdated(ctx, message)
+	case constants.EventTypeTaskDeleted:
+		return h.handleTaskLifecycle(ctx, message, h.validateTaskDeletedEvent, h.taskNotificationService.ProcessTaskDeletedEvent)
+	case constants.EventTypeTaskReassigned:
+		return h.handleTaskLifecycle(ctx, message, h.validateTaskReassignedEvent, h.taskNotificationService.ProcessTaskReassignedEvent)
+	case constants.EventTypeTaskStatusChanged:
+		return h.handleTaskLifecycle(ctx, message, h.validateTaskAssigneeEvent, h.taskNotificationService.ProcessTaskStatusChangedEvent)
+	case constants.EventTypeTaskCompleted:
+		return h.handleTaskLifecycle(ctx, message, h.validateTaskCompletedEvent, h.taskNotificationService.ProcessTaskCompletedEvent)
+	default:
+		logger.Warn("Skipping unsupported task event type",
+			zap.String("event_type", envelope.EventType),
+		)
+		return nil
+	}
+}
+
+func (h *TaskHandler) handleTaskLifecycle(
+	ctx context.Context,
+	message []byte,
+	validate func(*dto.TaskLifecycleEvent) error,
+	process func(context.Context, *dto.TaskLifecycleEvent) error,
+) error {
+	var event dto.TaskLifecycleEvent
+	if err := json.Unmarshal(message, &event); err != nil {
+		logger.Error("Failed to unmarshal task lifecycle event",
+			zap.Error(err),
+			zap.ByteString("message", message),
+		)
+		return errors.NewInvalidPayloadError("failed to unmarshal task lifecycle event", err)
+	}
+
+	logger.Info("Received task lifecycle event",
+		zap.String("event_type", event.EventType),
+		zap.String("task_id", event.Data.ID),
+		zap.String("actor_id", event.ActorID()),
+	)
+
+	if err := h.validateTaskLifecycleEvent(&event); err != nil {
+		logger.Error("Invalid task lifecycle event", zap.String("event_type", event.EventType), zap.Error(err))
+		return err
+	}
+
+	if err := validate(&event); err != nil {
+		logger.Error("Invalid task lifecycle event", zap.String("event_type", event.EventType), zap.Error(err))
+		return err
+	}
+
+	if err := process(ctx, &event); err != nil {
+		logger.Error("Failed to process task lifecycle event",
+			zap.Error(err),
+			zap.String("event_type", event.EventType),
+			zap.String("task_id", event.Data.ID),
+		)
+		return err
+	}
+
+	logger.Info("Successfully processed task lifecycle event",
+		zap.String("event_type", event.EventType),
+		zap.String("task_id", event.Data.ID),
+	)
+
+	return nil
+}
+
 func (h *TaskHandler) validateTaskCreatedEvent(event *dto.TaskCreatedEvent) error {
 	if event.Data.ID == "" {
 		return errors.NewInvalidPayloadError("task ID is required", nil)
@@ -104,8 +195,96 @@ func (h *TaskHandler) validateTaskCreatedEvent(event *dto.TaskCreatedEvent) erro
 		return errors.NewInvalidPayloadError("assigned user ID is required", nil)
 	}
 
-	if event.Metadata.AssignedToUser.FCMToken == "" {
-		return errors.NewInvalidPayloadError("FCM token is required", nil)
+	return validateSendAt(event.SendAt, event.Timestamp)
+}
+
+func (h *TaskHandler) validateTaskUpdatedEvent(event *dto.TaskUpdatedEvent) error {
+	if event.Data.ID == "" {
+		return errors.NewInvalidPayloadError("task ID is required", nil)
+	}
+
+	if event.Data.Title == "" {
+		return errors.NewInvalidPayloadError("task title is required", nil)
+	}
+
+	if len(event.Changes) == 0 {
+		return errors.NewInvalidPayloadError("change set is required", nil)
+	}
+
+	if event.Metadata.AssignedToUser.ID == "" {
+		return errors.NewInvalidPayloadError("assigned user ID is required", nil)
+	}
+
+	return validateSendAt(event.SendAt, event.Timestamp)
+}
+
+func (h *TaskHandler) validateTaskLifecycleEvent(event *dto.TaskLifecycleEvent) error {
+	if event.Data.ID == "" {
+		return errors.NewInvalidPayloadError("task ID is required", nil)
+	}
+
+	if event.Data.Title == "" {
+		return errors.NewInvalidPayloadError("task title is required", nil)
+	}
+
+	return validateSendAt(event.SendAt, event.Timestamp)
+}
+
+func (h *TaskHandler) validateTaskAssigneeEvent(event *dto.TaskLifecycleEvent) error {
+	if event.Metadata.AssignedToUser.ID == "" {
+		return errors.NewInvalidPayloadError("assigned user ID is required", nil)
+	}
+
+	return nil
+}
+
+func (h *TaskHandler) validateTaskDeletedEvent(event *dto.TaskLifecycleEvent) error {
+	if !event.Data.IsDeleted {
+		return errors.NewInvalidPayloadError("task is not marked as deleted", nil)
+	}
+
+	return h.validateTaskAssigneeEvent(event)
+}
+
+func (h *TaskHandler) validateTaskReassignedEvent(event *dto.TaskLifecycleEvent) error {
+	if err := h.validateTaskAssigneeEvent(event); err != nil {
+		return err
+	}
+
+	if event.Metadata.PreviousAssignedToUser.ID == "" {
+		return errors.NewInvalidPayloadError("previous assigned user ID is required", nil)
+	}
+
+	return nil
+}
+
+func (h *TaskHandler) validateTaskCompletedEvent(event *dto.TaskLifecycleEvent) error {
+	if event.Data.Status != constants.TaskStatusCompleted {
+		return errors.NewInvalidPayloadError("task is not in completed status", nil)
+	}
+
+	if event.Metadata.CreatedByUser.ID == "" {
+		return errors.NewInvalidPayloadError("task creator ID is required", nil)
+	}
+
+	return nil
+}
+
+// validateSendAt rejects a send_at that lies before the event was produced.
+// It is checked against the event timestamp rather than the clock, so an
+// event delivered late or replayed after its send time is sent right away
+// instead of being dead-lettered.
+func validateSendAt(sendAt *time.Time, timestamp time.Time) error {
+	if sendAt == nil {
+		return nil
+	}
+
+	producedAt := timestamp
+	if producedAt.IsZero() {
+		producedAt = time.Now()
+	}
+	if sendAt.Before(producedAt) {
+		return errors.NewInvalidPayloadError("send_at must not be in the past", nil)
 	}
 
 	return nil
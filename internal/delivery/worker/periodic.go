@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// Task is a unit of background work run on every tick
+type Task func(ctx context.Context) error
+
+// Periodic runs a task at a fixed interval until it is stopped
+type Periodic struct {
+	name     string
+	interval time.Duration
+	task     Task
+	wg       sync.WaitGroup
+	cancel   context.CancelFunc
+}
+
+func NewPeriodic(name string, interval time.Duration, task Task) *Periodic {
+	return &Periodic{
+		name:     name,
+		interval: interval,
+		task:     task,
+	}
+}
+
+// Start runs the task once immediately and then on every interval
+func (p *Periodic) Start(ctx context.Context) {
+	ctx, p.cancel = context.WithCancel(ctx)
+
+	p.wg.Add(1)
+	go p.run(ctx)
+
+	logger.Info("Started background worker",
+		zap.String("worker", p.name),
+		zap.Duration("interval", p.interval),
+	)
+}
+
+func (p *Periodic) run(ctx context.Context) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.task(ctx); err != nil && ctx.Err() == nil {
+			logger.Error("Background worker run failed",
+				zap.String("worker", p.name),
+				zap.Error(err),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop cancels the worker and waits for the current run to finish
+func (p *Periodic) Stop() {
+	if p.cancel == nil {
+		return
+	}
+
+	p.cancel()
+	p.wg.Wait()
+
+	logger.Info("Stopped background worker", zap.String("worker", p.name))
+}
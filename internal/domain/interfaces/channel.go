@@ -0,0 +1,45 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/corechain/notification-service/internal/domain/models"
+)
+
+// ChannelCapabilities describes which notifications a channel can deliver
+type ChannelCapabilities struct {
+	// Direct channels deliver to a single user
+	Direct bool
+	// Broadcast channels deliver to FCM topics and conditions
+	Broadcast bool
+}
+
+// Channel delivers notifications over one medium such as push, email or webhooks
+type Channel interface {
+	Name() string
+	Capabilities() ChannelCapabilities
+	// Send delivers a persisted notification. Provider failures are reported
+	// per attempt in the result; the error is only set when the channel could
+	// not attempt the delivery at all, e.g. because a lookup failed.
+	Send(ctx context.Context, notification *models.Notification) (*ChannelResult, error)
+}
+
+// ChannelResult is the outcome of sending a notification over one channel
+type ChannelResult struct {
+	Attempts []ChannelAttempt
+	// Deliveries is the per-device breakdown of channels that fan out to devices
+	Deliveries []models.DeviceDelivery
+	// SkipReason is set when the channel had nobody to deliver to, such as a
+	// user without a registered device
+	SkipReason string
+}
+
+// ChannelAttempt is one call to a provider. Err is nil on success.
+type ChannelAttempt struct {
+	Provider  string
+	MessageID string
+	StartedAt time.Time
+	Latency   time.Duration
+	Err       error
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import "context"
+
+// RealtimeEvent announces a newly persisted notification of a user
+type RealtimeEvent struct {
+	NotificationID string `json:"id"`
+	UserID         string `json:"user_id"`
+}
+
+// RealtimeBroker fans realtime events out to every replica of the service
+type RealtimeBroker interface {
+	Publish(ctx context.Context, event RealtimeEvent) error
+	// Listen calls handle for every event published by any replica, including
+	// this one, until ctx is done. Lost connections are re-established.
+	Listen(ctx context.Context, handle func(RealtimeEvent))
+}
@@ -2,29 +2,273 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/corechain/notification-service/internal/domain/models"
 )
 
 type NotificationRepository interface {
+	// Create stores a notification. A notification with an idempotency key
+	// that was already created for the same user and type is not stored
+	// again; Create returns an ErrCodeDuplicate error instead.
 	Create(ctx context.Context, notification *models.Notification) error
+	// CreateCoalesced stores a notification as scheduled in place of the
+	// scheduled notifications sharing its coalesce key, which become
+	// superseded. It is sent when the earliest of them was due, or at sendAt
+	// when there were none. It returns how many notifications were superseded
+	// and fails like Create for a duplicate.
+	CreateCoalesced(ctx context.Context, notification *models.Notification, sendAt time.Time) (int64, error)
 	Update(ctx context.Context, notification *models.Notification) error
 	GetByID(ctx context.Context, id string) (*models.Notification, error)
-	GetByUserID(ctx context.Context, userID string, limit, offset int) ([]*models.Notification, error)
+	// GetByUserID returns one page of the notifications of a user
+	GetByUserID(ctx context.Context, userID string, query models.NotificationQuery) ([]*models.Notification, error)
+	// GetByIdempotencyKey returns the notification of the given type an event
+	// created for a user
+	GetByIdempotencyKey(ctx context.Context, key string, userID string, notificationType models.NotificationType) (*models.Notification, error)
+	// CountByUserID counts the notifications of a user matching filter
+	CountByUserID(ctx context.Context, userID string, filter models.NotificationFilter) (int64, error)
+	// MarkAnnounced gives a notification the next position in the realtime
+	// streams; announcing it again moves it to the end
+	MarkAnnounced(ctx context.Context, id string) error
+	// GetAnnouncedAfter returns up to limit notifications of a user announced
+	// after the notification afterID, in announcement order, leaving out those
+	// no longer in the inbox. It returns nothing when afterID is unknown or was
+	// never announced.
+	GetAnnouncedAfter(ctx context.Context, userID string, afterID string, limit int) ([]*models.Notification, error)
+	// GetByDigestID returns the notifications collected in a digest, newest first
+	GetByDigestID(ctx context.Context, digestID string) ([]*models.Notification, error)
 	GetPendingNotifications(ctx context.Context, limit int) ([]*models.Notification, error)
 	UpdateStatus(ctx context.Context, id string, status string, errorMsg string) error
+	// ClaimScheduled leases up to limit scheduled or deferred notifications
+	// whose send time has passed by moving them to pending, and takes over
+	// pending notifications whose lease has expired. Rows locked by another
+	// replica are skipped.
+	ClaimScheduled(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.Notification, error)
+	// ClaimPending leases a pending notification whose lease has expired, or
+	// that never had one, and reports whether it did
+	ClaimPending(ctx context.Context, id string, now time.Time, lease time.Duration) (bool, error)
+	// CancelScheduled cancels a notification that has not been sent yet
+	CancelScheduled(ctx context.Context, id string) error
+	// Defer holds a notification back until the given time, when the
+	// scheduler picks it up again
+	Defer(ctx context.Context, id string, until time.Time) error
+	// ReleaseDeferred makes the deferred notifications of a user due now, so
+	// the scheduler checks them against the user's current preferences
+	ReleaseDeferred(ctx context.Context, userID string) (int64, error)
+	// ClaimRetryable leases up to limit failed notifications whose next
+	// attempt is due by pushing that attempt past the lease, increments their
+	// retry count and returns them. Rows locked by another replica are
+	// skipped, and a notification whose worker died is retried again once the
+	// lease expires.
+	ClaimRetryable(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.Notification, error)
+	// ScheduleRetry marks a notification as failed and records when to try again
+	ScheduleRetry(ctx context.Context, id string, nextRetryAt time.Time, errorMsg string) error
+	// UpdateDeliveryResults stores the per-channel status and the per-device
+	// breakdown of the latest delivery
+	UpdateDeliveryResults(ctx context.Context, id string, channels []models.ChannelDelivery, deliveries []models.DeviceDelivery) error
+	// MarkRead marks a notification, and thereby also seen, as read and
+	// returns it. Notifications that are already read keep their read time.
+	MarkRead(ctx context.Context, id string) (*models.Notification, error)
+	// MarkAllRead marks the unread inbox notifications of a user matching
+	// filter as read and returns how many changed
+	MarkAllRead(ctx context.Context, userID string, filter models.NotificationFilter) (int64, error)
+	// MarkAllSeen marks the unseen inbox notifications of a user matching
+	// filter as seen and returns how many changed
+	MarkAllSeen(ctx context.Context, userID string, filter models.NotificationFilter) (int64, error)
+	// CountUnread counts the unread and the unseen inbox notifications of a user
+	CountUnread(ctx context.Context, userID string) (*models.UnreadCount, error)
+}
+
+type NotificationAttemptRepository interface {
+	Create(ctx context.Context, attempt *models.NotificationAttempt) error
+	GetByNotificationID(ctx context.Context, notificationID string) ([]*models.NotificationAttempt, error)
+}
+
+type DeviceTokenRepository interface {
+	// Upsert registers a device or replaces its token. A token that moved to
+	// another device or user is detached from its previous owner.
+	Upsert(ctx context.Context, device *models.DeviceToken) error
+	// Refresh replaces the token of an already registered device
+	Refresh(ctx context.Context, userID string, deviceID string, fcmToken string, appVersion string) (*models.DeviceToken, error)
+	GetByUserID(ctx context.Context, userID string) ([]*models.DeviceToken, error)
+	// GetActiveByUserID returns the active devices of a user, most recently updated first
+	GetActiveByUserID(ctx context.Context, userID string) ([]*models.DeviceToken, error)
+	// Delete removes a device and returns it
+	Delete(ctx context.Context, userID string, deviceID string) (*models.DeviceToken, error)
+	// DeactivateToken marks the device holding a token as inactive and returns
+	// it, or returns nil when no active device holds the token
+	DeactivateToken(ctx context.Context, fcmToken string) (*models.DeviceToken, error)
+}
+
+type WebPushSubscriptionRepository interface {
+	// Upsert stores a subscription, or refreshes the keys and owner of a
+	// known endpoint
+	Upsert(ctx context.Context, subscription *models.WebPushSubscription) error
+	// GetByUserID returns the subscriptions of a user, most recently updated first
+	GetByUserID(ctx context.Context, userID string) ([]*models.WebPushSubscription, error)
+	Delete(ctx context.Context, userID string, id string) error
+	// DeleteByEndpoint removes an expired endpoint; unknown endpoints are ignored
+	DeleteByEndpoint(ctx context.Context, endpoint string) error
+}
+
+type NotificationPreferenceRepository interface {
+	// GetByUserID returns the preferences of a user; users who never stored
+	// any get empty preferences
+	GetByUserID(ctx context.Context, userID string) (*models.NotificationPreferences, error)
+	// Replace stores the preferences of a user, overrides included, and
+	// keeps their do-not-disturb time
+	Replace(ctx context.Context, preferences *models.NotificationPreferences) error
+	// SetDoNotDisturb sets or, with nil, clears the do-not-disturb time of a
+	// user without touching their other preferences
+	SetDoNotDisturb(ctx context.Context, userID string, until *time.Time) error
+	Delete(ctx context.Context, userID string) error
+}
+
+type NotificationDigestRepository interface {
+	// AddItem adds a notification to the open digest of its user and project,
+	// opening one that closes at sendAt when there is none, and marks the
+	// notification as digested
+	AddItem(ctx context.Context, notification *models.Notification, sendAt time.Time) (*models.NotificationDigest, error)
+	// ClaimDue leases up to limit digests whose window has closed by pushing
+	// their send time past the lease. Rows locked by another replica are
+	// skipped, and a digest whose worker died is picked up again once the
+	// lease expires.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.NotificationDigest, error)
+	// MarkSent closes a digest and links the summary notification announcing
+	// it; an empty notificationID closes it without one
+	MarkSent(ctx context.Context, id string, notificationID string) error
+	GetByID(ctx context.Context, id string) (*models.NotificationDigest, error)
+}
+
+type TopicSubscriptionRepository interface {
+	// Add records that a user's devices belong on an FCM topic; adding twice is a no-op
+	Add(ctx context.Context, userID string, topic string) error
+	Remove(ctx context.Context, userID string, topic string) error
+	GetTopicsByUserID(ctx context.Context, userID string) ([]string, error)
+	// CountUsers returns how many distinct users are subscribed to any of the topics
+	CountUsers(ctx context.Context, topics []string) (int, error)
+}
+
+type WebhookSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *models.WebhookSubscription) error
+	Update(ctx context.Context, subscription *models.WebhookSubscription) error
+	GetByID(ctx context.Context, id string) (*models.WebhookSubscription, error)
+	// List returns the subscriptions of a project, or all of them when projectID is empty
+	List(ctx context.Context, projectID string) ([]*models.WebhookSubscription, error)
+	Delete(ctx context.Context, id string) error
+	// GetMatching returns the active subscriptions whose filters match a notification
+	GetMatching(ctx context.Context, projectID string, notificationType string) ([]*models.WebhookSubscription, error)
+	// RecordSuccess resets the consecutive failure count of a subscription
+	RecordSuccess(ctx context.Context, id string) error
+	// RecordFailure increments the consecutive failure count and disables the
+	// subscription once it reaches disableAfter. It reports whether this call
+	// disabled the subscription.
+	RecordFailure(ctx context.Context, id string, disableAfter int, reason string) (bool, error)
+}
+
+type WebhookDeliveryRepository interface {
+	CreateBatch(ctx context.Context, deliveries []*models.WebhookDelivery) error
+	// ClaimDue leases up to limit pending deliveries of active subscriptions
+	// whose next attempt is due by pushing their next attempt past the lease.
+	// Rows locked by another replica are skipped, and a delivery whose worker
+	// died is picked up again once the lease expires.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.WebhookDelivery, error)
+	MarkDelivered(ctx context.Context, id string, statusCode int) error
+	ScheduleRetry(ctx context.Context, id string, nextAttemptAt time.Time, statusCode int, errorMsg string) error
+	MarkFailed(ctx context.Context, id string, statusCode int, errorMsg string) error
+	// FailPending gives up on every pending delivery of a subscription
+	FailPending(ctx context.Context, subscriptionID string, reason string) error
+	// GetBySubscriptionID returns the latest deliveries of a subscription, newest first
+	GetBySubscriptionID(ctx context.Context, subscriptionID string, limit int) ([]*models.WebhookDelivery, error)
+}
+
+type TaskReminderRepository interface {
+	// ReplaceForTask cancels the pending reminders of a task and schedules the given ones instead
+	ReplaceForTask(ctx context.Context, taskID string, reminders []*models.TaskReminder) error
+	CancelForTask(ctx context.Context, taskID string) error
+	// ClaimDue leases up to limit pending reminders that are due by pushing
+	// claimed_until past the lease. Rows locked by another replica are skipped,
+	// and a reminder whose worker died is picked up again once the lease expires.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.TaskReminder, error)
+	// MarkSent records that the notification of a pending reminder was created
+	MarkSent(ctx context.Context, id string, sentAt time.Time) error
 }
 
 type FCMClient interface {
-	SendNotification(ctx context.Context, token string, title string, body string, data map[string]string) error
-	SendBatchNotifications(ctx context.Context, notifications []FCMMessage) error
+	// SendNotification sends a notification to one device and returns the provider message ID
+	SendNotification(ctx context.Context, token string, title string, body string, data map[string]string) (string, error)
+	// SendMulticast sends one notification to several devices. The results hold
+	// the outcome of every token in input order; the error is only set when the
+	// request as a whole failed.
+	SendMulticast(ctx context.Context, tokens []string, title string, body string, data map[string]string, options FCMSendOptions) ([]FCMSendResult, error)
+	// SendBatchNotifications sends independent messages and reports the outcome
+	// of each one in input order
+	SendBatchNotifications(ctx context.Context, notifications []FCMMessage) ([]FCMSendResult, error)
+	// SendToAudience sends one notification to an FCM topic or, when topic is
+	// empty, to a condition expression and returns the provider message ID
+	SendToAudience(ctx context.Context, topic string, condition string, title string, body string, data map[string]string) (string, error)
+	SubscribeToTopic(ctx context.Context, tokens []string, topic string) error
+	UnsubscribeFromTopic(ctx context.Context, tokens []string, topic string) error
+}
+
+// FCMSendOptions tune how devices present a notification
+type FCMSendOptions struct {
+	// Badge is the app icon badge on iOS; nil leaves the badge unchanged
+	Badge *int
+	// CollapseKey makes the notification replace an earlier one with the same
+	// key on the device; empty shows every notification on its own
+	CollapseKey string
 }
 
 type FCMMessage struct {
-	Token string
-	Title string
-	Body  string
-	Data  map[string]string
+	Token   string
+	Title   string
+	Body    string
+	Data    map[string]string
+	Options FCMSendOptions
+}
+
+// FCMSendResult is the outcome of sending to one token. Err is nil on success.
+type FCMSendResult struct {
+	Token     string
+	MessageID string
+	Err       error
+}
+
+// WebhookClient posts signed webhook payloads
+type WebhookClient interface {
+	// Post sends the payload to url signed with secret and returns the HTTP
+	// status code. Non-2xx responses are reported as errors.
+	Post(ctx context.Context, url string, secret string, deliveryID string, payload []byte) (int, error)
+}
+
+// WebPushClient sends encrypted Web Push messages
+type WebPushClient interface {
+	// Send encrypts payload for the subscription and hands it to its push
+	// service. It returns the message location reported by the push service.
+	Send(ctx context.Context, subscription *models.WebPushSubscription, payload []byte, urgency string) (string, error)
+	// PublicKey returns the base64url encoded VAPID public key browsers
+	// subscribe with
+	PublicKey() string
+}
+
+// EmailSender delivers rendered emails
+type EmailSender interface {
+	// Send delivers one email and returns its Message-ID
+	Send(ctx context.Context, message EmailMessage) (string, error)
+}
+
+// EmailMessage is an email with an HTML body and a plain-text alternative
+type EmailMessage struct {
+	To       string
+	Subject  string
+	HTMLBody string
+	TextBody string
+}
+
+// EventPublisher publishes domain events for other services
+type EventPublisher interface {
+	PublishJSON(ctx context.Context, topic string, key string, payload interface{}) error
 }
 
 type KafkaConsumer interface {
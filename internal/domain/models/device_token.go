@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// DeviceToken is an FCM registration token of one of a user's devices
+type DeviceToken struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"user_id"`
+	DeviceID    string    `json:"device_id"`
+	FCMToken    string    `json:"fcm_token"`
+	Platform    string    `json:"platform"`
+	AppVersion  string    `json:"app_version,omitempty"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastUpdated time.Time `json:"last_updated"`
+}
@@ -13,6 +13,7 @@ type Notification struct {
 	NotificationType NotificationType              `json:"notification_type"`
 	UserID           string                        `json:"user_id"`
 	FCMToken         string                        `json:"fcm_token"`
+	RecipientEmail   string                        `json:"recipient_email,omitempty"`
 	Title            string                        `json:"title"`
 	Body             string                        `json:"body"`
 	Data             map[string]interface{}        `json:"data"`
@@ -20,10 +21,53 @@ type Notification struct {
 	ErrorMessage     string                        `json:"error_message,omitempty"`
 	CreatedAt        time.Time                     `json:"created_at"`
 	SentAt           *time.Time                    `json:"sent_at,omitempty"`
+	SendAt           *time.Time                    `json:"send_at,omitempty"`
+	// ClaimedUntil is when another replica may take over a pending
+	// notification whose sender died
+	ClaimedUntil     *time.Time                    `json:"-"`
 	RetryCount       int                           `json:"retry_count"`
+	NextRetryAt      *time.Time                    `json:"next_retry_at,omitempty"`
+	ReadAt           *time.Time                    `json:"read_at,omitempty"`
+	SeenAt           *time.Time                    `json:"seen_at,omitempty"`
 	TaskID           string                        `json:"task_id,omitempty"`
 	ProjectID        string                        `json:"project_id,omitempty"`
 	Priority         int                           `json:"priority,omitempty"`
+	// DigestID is the digest that announced the notification instead of a push
+	DigestID         string                        `json:"digest_id,omitempty"`
+	// CoalesceKey groups updates of one task so only the latest is sent
+	CoalesceKey      string                        `json:"coalesce_key,omitempty"`
+	// IdempotencyKey identifies the event the notification was created for
+	IdempotencyKey   string                        `json:"idempotency_key,omitempty"`
+	Channels         []ChannelDelivery             `json:"channels,omitempty"`
+	Deliveries       []DeviceDelivery              `json:"deliveries,omitempty"`
+	// TargetTopic or TargetCondition address a broadcast instead of one user
+	TargetTopic      string                        `json:"target_topic,omitempty"`
+	TargetCondition  string                        `json:"target_condition,omitempty"`
+	RecipientCount   int                           `json:"recipient_count,omitempty"`
+}
+
+// IsBroadcast reports whether the notification goes to an FCM topic or condition
+func (n *Notification) IsBroadcast() bool {
+	return n.TargetTopic != "" || n.TargetCondition != ""
+}
+
+// ChannelDelivery is the delivery state of a notification on one channel
+type ChannelDelivery struct {
+	Channel   string                  `json:"channel"`
+	Status    constants.ChannelStatus `json:"status"`
+	Error     string                  `json:"error,omitempty"`
+	UpdatedAt time.Time               `json:"updated_at"`
+}
+
+// DeviceDelivery is the outcome of the latest delivery of a notification to one device
+type DeviceDelivery struct {
+	DeviceID     string                  `json:"device_id,omitempty"`
+	Platform     string                  `json:"platform,omitempty"`
+	Token        string                  `json:"token"`
+	Status       constants.AttemptStatus `json:"status"`
+	MessageID    string                  `json:"message_id,omitempty"`
+	ErrorCode    string                  `json:"error_code,omitempty"`
+	ErrorMessage string                  `json:"error_message,omitempty"`
 }
 
 type UserInfo struct {
@@ -40,6 +84,7 @@ type Task struct {
 	CreatedBy   UserInfo   `json:"createdBy"`
 	AssignedTo  string     `json:"assignedTo"`  // ObjectId reference
 	ProjectID   string     `json:"projectId"`
+	ProjectName string     `json:"projectName,omitempty"`
 	Priority    int        `json:"priority"`
 	Status      int        `json:"status"`
 	StartDate   *time.Time `json:"startDate"`
@@ -53,14 +98,18 @@ type Task struct {
 }
 
 type Message struct {
-	ID        string    `json:"_id"`
-	Content   string    `json:"content"`
-	SenderID  string    `json:"senderId"`
-	CreatedAt time.Time `json:"createdAt"`
+	ID             string    `json:"_id"`
+	Content        string    `json:"content"`
+	SenderID       string    `json:"senderId"`
+	ReceiverID     string    `json:"receiverId"`
+	ConversationID string    `json:"conversationId"`
+	CreatedAt      time.Time `json:"createdAt"`
 }
 
 type Call struct {
-	ID       string `json:"_id"`
-	CallerID string `json:"callerId"`
-	CallType string `json:"callType"`
+	ID         string    `json:"_id"`
+	CallerID   string    `json:"callerId"`
+	ReceiverID string    `json:"receiverId"`
+	CallType   string    `json:"callType"`
+	CreatedAt  time.Time `json:"createdAt"`
 }
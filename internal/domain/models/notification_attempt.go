@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/corechain/notification-service/pkg/constants"
+)
+
+type AttemptStatus = constants.AttemptStatus
+
+// NotificationAttempt records the outcome of one delivery attempt
+type NotificationAttempt struct {
+	ID                string        `json:"id"`
+	NotificationID    string        `json:"notification_id"`
+	AttemptNumber     int           `json:"attempt_number"`
+	Provider          string        `json:"provider"`
+	ProviderMessageID string        `json:"provider_message_id,omitempty"`
+	Status            AttemptStatus `json:"status"`
+	ErrorCode         string        `json:"error_code,omitempty"`
+	ErrorMessage      string        `json:"error_message,omitempty"`
+	LatencyMs         int64         `json:"latency_ms"`
+	CreatedAt         time.Time     `json:"created_at"`
+}
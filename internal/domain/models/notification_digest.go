@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/corechain/notification-service/pkg/constants"
+)
+
+type DigestStatus = constants.DigestStatus
+
+// NotificationDigest collects the low-priority task notifications of one user
+// and project over a window; once the window closes they are announced with a
+// single summary notification
+type NotificationDigest struct {
+	ID        string       `json:"id"`
+	UserID    string       `json:"user_id"`
+	ProjectID string       `json:"project_id,omitempty"`
+	Status    DigestStatus `json:"status"`
+	ItemCount int          `json:"item_count"`
+	SendAt    time.Time    `json:"send_at"`
+	// NotificationID is the summary notification, set once the digest is sent
+	NotificationID string          `json:"notification_id,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	SentAt         *time.Time      `json:"sent_at,omitempty"`
+	Items          []*Notification `json:"items,omitempty"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// NotificationFilter narrows the notifications of a user. Empty fields match
+// every value.
+type NotificationFilter struct {
+	NotificationType NotificationType
+	Statuses         []NotificationStatus
+	ProjectID        string
+	TaskID           string
+	// Read selects read (true) or unread (false) notifications
+	Read *bool
+	// CreatedFrom is inclusive, CreatedTo exclusive
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+}
+
+// NotificationCursor is the position of the last notification of a page
+type NotificationCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// NotificationQuery selects one page of the notifications of a user, newest
+// first unless Ascending is set. A page continues after After when it is set.
+type NotificationQuery struct {
+	Filter    NotificationFilter
+	After     *NotificationCursor
+	Ascending bool
+	Limit     int
+	Offset    int
+}
+
+// NotificationPage is one page of the notification list. NextCursor is empty
+// on the last page; Total is only set when it was asked for.
+type NotificationPage struct {
+	Notifications []*Notification `json:"notifications"`
+	NextCursor    string          `json:"next_cursor,omitempty"`
+	Total         *int64          `json:"total,omitempty"`
+}
+
+// UnreadCount is the inbox state of a user
+type UnreadCount struct {
+	Unread int64 `json:"unread"`
+	Unseen int64 `json:"unseen"`
+}
@@ -0,0 +1,198 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// NotificationPreferences are a user's choices about which notifications
+// reach them and on which channels. Channels and Muted apply to every
+// notification; overrides narrow them to a notification type, a project or
+// both.
+type NotificationPreferences struct {
+	UserID string `json:"user_id"`
+	// Channels replaces the routed channels of every notification; nil keeps
+	// the service's routing
+	Channels  []string             `json:"channels"`
+	Muted     bool                 `json:"muted"`
+	Overrides []PreferenceOverride `json:"overrides"`
+	// QuietHours hold back notifications that aren't urgent every day
+	QuietHours *QuietHours `json:"quiet_hours"`
+	// DoNotDisturbUntil holds them back once, until the given time
+	DoNotDisturbUntil *time.Time `json:"do_not_disturb_until"`
+	// Digest collects low-priority task notifications into periodic summaries
+	Digest bool `json:"digest"`
+	// UpdatedAt is nil while the user has never stored preferences
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// QuietHours is a daily window in the user's time zone. Start and End are
+// "15:04" clock times; a window that ends before it starts runs past midnight.
+type QuietHours struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	TimeZone string `json:"time_zone"`
+}
+
+// PreferenceOverride changes the preferences for one notification type, one
+// project or one type within a project. Nil fields inherit the less specific
+// setting.
+type PreferenceOverride struct {
+	NotificationType NotificationType `json:"notification_type,omitempty"`
+	ProjectID        string           `json:"project_id,omitempty"`
+	Channels         []string         `json:"channels"`
+	Muted            *bool            `json:"muted"`
+}
+
+// PreferenceDecision is the outcome of a user's preferences for one
+// notification
+type PreferenceDecision struct {
+	// Channels replaces the routed channels when it is not nil
+	Channels []string
+	Muted    bool
+	// Reason says why the notification is suppressed
+	Reason string
+	// DeferUntil holds the notification back until quiet hours or
+	// do-not-disturb end
+	DeferUntil *time.Time
+	// Digest adds the notification to the user's digest instead of sending it
+	Digest bool
+}
+
+// Suppressed reports whether the notification must not be sent at all
+func (d PreferenceDecision) Suppressed() bool {
+	return d.Reason != ""
+}
+
+// Resolve applies the preferences to a notification. Each setting comes from
+// the most specific override that sets it: type within project, then
+// project, then type, then the user's defaults.
+func (p *NotificationPreferences) Resolve(notificationType NotificationType, projectID string) PreferenceDecision {
+	decision := PreferenceDecision{
+		Channels: p.Channels,
+		Muted:    p.Muted,
+	}
+	mutedBy, channelsBy := "all notifications", "all notifications"
+	mutedRank, channelsRank := 0, 0
+
+	for _, override := range p.Overrides {
+		rank := override.rank(notificationType, projectID)
+		if rank == 0 {
+			continue
+		}
+		if override.Muted != nil && rank > mutedRank {
+			decision.Muted = *override.Muted
+			mutedBy, mutedRank = override.scope(), rank
+		}
+		if override.Channels != nil && rank > channelsRank {
+			decision.Channels = override.Channels
+			channelsBy, channelsRank = override.scope(), rank
+		}
+	}
+
+	switch {
+	case decision.Muted:
+		decision.Reason = "muted by preference for " + mutedBy
+	case decision.Channels != nil && len(decision.Channels) == 0:
+		decision.Reason = "every channel disabled by preference for " + channelsBy
+	}
+
+	return decision
+}
+
+// QuietUntil returns when the user may be disturbed again, or nil when they
+// may be disturbed at now
+func (p *NotificationPreferences) QuietUntil(now time.Time) *time.Time {
+	var until *time.Time
+	if p.DoNotDisturbUntil != nil && p.DoNotDisturbUntil.After(now) {
+		until = p.DoNotDisturbUntil
+	}
+	if p.QuietHours != nil {
+		if end := p.QuietHours.EndOf(now); end != nil && (until == nil || end.After(*until)) {
+			until = end
+		}
+	}
+	return until
+}
+
+// EndOf returns when the quiet window containing t ends, or nil when t lies
+// outside the window or the window is invalid
+func (q *QuietHours) EndOf(t time.Time) *time.Time {
+	location, err := time.LoadLocation(q.TimeZone)
+	if err != nil {
+		return nil
+	}
+	start, okStart := ParseClock(q.Start)
+	end, okEnd := ParseClock(q.End)
+	if !okStart || !okEnd || start == end {
+		return nil
+	}
+
+	local := t.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	day := local
+	if start < end {
+		if minute < start || minute >= end {
+			return nil
+		}
+	} else {
+		if minute >= end && minute < start {
+			return nil
+		}
+		if minute >= start {
+			day = local.AddDate(0, 0, 1)
+		}
+	}
+
+	endAt := time.Date(day.Year(), day.Month(), day.Day(), end/60, end%60, 0, 0, location)
+	// An end the spring-forward change skips may come back as the hour before
+	// it, which could lie before t; move it past the change instead, to where
+	// the clock would have been had it not jumped
+	if skipped := end - (endAt.Hour()*60 + endAt.Minute()); skipped != 0 {
+		if skipped < 0 {
+			skipped += 24 * 60
+		}
+		endAt = endAt.Add(time.Duration(skipped) * time.Minute)
+	}
+	return &endAt
+}
+
+// ParseClock parses a "15:04" clock time into minutes after midnight
+func ParseClock(clock string) (int, bool) {
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, false
+	}
+	return parsed.Hour()*60 + parsed.Minute(), true
+}
+
+// rank is how specific the override is for a notification, or 0 when it does
+// not apply to it
+func (o PreferenceOverride) rank(notificationType NotificationType, projectID string) int {
+	if o.NotificationType != "" && o.NotificationType != notificationType {
+		return 0
+	}
+	if o.ProjectID != "" && o.ProjectID != projectID {
+		return 0
+	}
+
+	rank := 0
+	if o.NotificationType != "" {
+		rank++
+	}
+	if o.ProjectID != "" {
+		rank += 2
+	}
+	return rank
+}
+
+func (o PreferenceOverride) scope() string {
+	var parts []string
+	if o.NotificationType != "" {
+		parts = append(parts, "type "+string(o.NotificationType))
+	}
+	if o.ProjectID != "" {
+		parts = append(parts, "project "+o.ProjectID)
+	}
+	return strings.Join(parts, " in ")
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuietHoursEndOf(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	newYork := mustLoadLocation(t, "America/New_York")
+
+	tests := []struct {
+		name  string
+		hours QuietHours
+		at    time.Time
+		want  *time.Time
+	}{
+		{
+			name:  "inside a window within one day",
+			hours: QuietHours{Start: "09:00", End: "17:00", TimeZone: "Europe/Berlin"},
+			at:    time.Date(2025, 6, 2, 12, 0, 0, 0, berlin),
+			want:  timePtr(time.Date(2025, 6, 2, 17, 0, 0, 0, berlin)),
+		},
+		{
+			name:  "before a window within one day",
+			hours: QuietHours{Start: "09:00", End: "17:00", TimeZone: "Europe/Berlin"},
+			at:    time.Date(2025, 6, 2, 8, 59, 0, 0, berlin),
+		},
+		{
+			name:  "exactly at the start",
+			hours: QuietHours{Start: "09:00", End: "17:00", TimeZone: "Europe/Berlin"},
+			at:    time.Date(2025, 6, 2, 9, 0, 0, 0, berlin),
+			want:  timePtr(time.Date(2025, 6, 2, 17, 0, 0, 0, berlin)),
+		},
+		{
+			name:  "exactly at the end",
+			hours: QuietHours{Start: "09:00", End: "17:00", TimeZone: "Europe/Berlin"},
+			at:    time.Date(2025, 6, 2, 17, 0, 0, 0, berlin),
+		},
+		{
+			name:  "before midnight in a window spanning midnight",
+			hours: QuietHours{Start: "22:00", End: "07:00", TimeZone: "Europe/Berlin"},
+			at:    time.Date(2025, 6, 2, 23, 30, 0, 0, berlin),
+			want:  timePtr(time.Date(2025, 6, 3, 7, 0, 0, 0, berlin)),
+		},
+		{
+			name:  "after midnight in a window spanning midnight",
+			hours: QuietHours{Start: "22:00", End: "07:00", TimeZone: "Europe/Berlin"},
+			at:    time.Date(2025, 6, 3, 2, 0, 0, 0, berlin),
+			want:  timePtr(time.Date(2025, 6, 3, 7, 0, 0, 0, berlin)),
+		},
+		{
+			name:  "exactly at the start of a window spanning midnight",
+			hours: QuietHours{Start: "22:00", End: "07:00", TimeZone: "Europe/Berlin"},
+			at:    time.Date(2025, 6, 2, 22, 0, 0, 0, berlin),
+			want:  timePtr(time.Date(2025, 6, 3, 7, 0, 0, 0, berlin)),
+		},
+		{
+			name:  "exactly at the end of a window spanning midnight",
+			hours: QuietHours{Start: "22:00", End: "07:00", TimeZone: "Europe/Berlin"},
+			at:    time.Date(2025, 6, 3, 7, 0, 0, 0, berlin),
+		},
+		{
+			name:  "during the day outside a window spanning midnight",
+			hours: QuietHours{Start: "22:00", End: "07:00", TimeZone: "Europe/Berlin"},
+			at:    time.Date(2025, 6, 3, 12, 0, 0, 0, berlin),
+		},
+		{
+			name:  "time given in another zone",
+			hours: QuietHours{Start: "22:00", End: "07:00", TimeZone: "Europe/Berlin"},
+			at:    time.Date(2025, 6, 2, 21, 30, 0, 0, time.UTC), // 23:30 in Berlin
+			want:  timePtr(time.Date(2025, 6, 3, 7, 0, 0, 0, berlin)),
+		},
+		{
+			// Clocks jump from 02:00 to 03:00, so 02:30 doesn't exist that night
+			name:  "end skipped by the spring-forward change",
+			hours: QuietHours{Start: "22:00", End: "02:30", TimeZone: "America/New_York"},
+			at:    time.Date(2024, 3, 9, 23, 0, 0, 0, newYork),
+			want:  timePtr(time.Date(2024, 3, 10, 7, 30, 0, 0, time.UTC)), // 03:30 EDT
+		},
+		{
+			name:  "just before the spring-forward change",
+			hours: QuietHours{Start: "22:00", End: "02:30", TimeZone: "America/New_York"},
+			at:    time.Date(2024, 3, 10, 1, 45, 0, 0, newYork),
+			want:  timePtr(time.Date(2024, 3, 10, 7, 30, 0, 0, time.UTC)),
+		},
+		{
+			name:  "after the skipped end",
+			hours: QuietHours{Start: "22:00", End: "02:30", TimeZone: "America/New_York"},
+			at:    time.Date(2024, 3, 10, 3, 30, 0, 0, newYork),
+		},
+		{
+			name:  "unknown time zone",
+			hours: QuietHours{Start: "22:00", End: "07:00", TimeZone: "Mars/Olympus_Mons"},
+			at:    time.Date(2025, 6, 2, 23, 30, 0, 0, time.UTC),
+		},
+		{
+			name:  "empty window",
+			hours: QuietHours{Start: "22:00", End: "22:00", TimeZone: "Europe/Berlin"},
+			at:    time.Date(2025, 6, 2, 22, 0, 0, 0, berlin),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.hours.EndOf(tt.at)
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("EndOf(%s) = %s, want nil", tt.at, got)
+			case tt.want != nil && got == nil:
+				t.Errorf("EndOf(%s) = nil, want %s", tt.at, tt.want)
+			case tt.want != nil && !got.Equal(*tt.want):
+				t.Errorf("EndOf(%s) = %s, want %s", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuietUntilPrefersTheLaterEnd(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	now := time.Date(2025, 6, 2, 23, 0, 0, 0, berlin)
+	quietEnd := time.Date(2025, 6, 3, 7, 0, 0, 0, berlin)
+
+	tests := []struct {
+		name         string
+		doNotDisturb *time.Time
+		want         *time.Time
+	}{
+		{name: "quiet hours only", want: &quietEnd},
+		{name: "do-not-disturb ends earlier", doNotDisturb: timePtr(now.Add(time.Hour)), want: &quietEnd},
+		{name: "do-not-disturb ends later", doNotDisturb: timePtr(quietEnd.Add(time.Hour)), want: timePtr(quietEnd.Add(time.Hour))},
+		{name: "do-not-disturb already over", doNotDisturb: timePtr(now.Add(-time.Hour)), want: &quietEnd},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preferences := &NotificationPreferences{
+				QuietHours:        &QuietHours{Start: "22:00", End: "07:00", TimeZone: "Europe/Berlin"},
+				DoNotDisturbUntil: tt.doNotDisturb,
+			}
+			if got := preferences.QuietUntil(now); got == nil || !got.Equal(*tt.want) {
+				t.Errorf("QuietUntil = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load %s: %v", name, err)
+	}
+	return location
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
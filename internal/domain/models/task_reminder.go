@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/corechain/notification-service/pkg/constants"
+)
+
+type ReminderType = constants.ReminderType
+type ReminderStatus = constants.ReminderStatus
+
+// TaskReminder is a due-date reminder scheduled for the assignee of a task
+type TaskReminder struct {
+	ID           string         `json:"id"`
+	TaskID       string         `json:"task_id"`
+	UserID       string         `json:"user_id"`
+	FCMToken     string         `json:"fcm_token"`
+	Email        string         `json:"email,omitempty"`
+	TaskTitle    string         `json:"task_title"`
+	ProjectID    string         `json:"project_id,omitempty"`
+	Priority     int            `json:"priority,omitempty"`
+	DueDate      time.Time      `json:"due_date"`
+	ReminderType ReminderType   `json:"reminder_type"`
+	RemindAt     time.Time      `json:"remind_at"`
+	Status       ReminderStatus `json:"status"`
+	CreatedAt    time.Time      `json:"created_at"`
+	SentAt       *time.Time     `json:"sent_at,omitempty"`
+	// ClaimedUntil is when another replica may send a reminder whose sender died
+	ClaimedUntil *time.Time `json:"-"`
+}
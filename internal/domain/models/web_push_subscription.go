@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// WebPushSubscription is the push subscription of one of a user's browsers.
+// P256dh and Auth are the base64url encoded keys the payload is encrypted for.
+type WebPushSubscription struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"user_id"`
+	Endpoint    string    `json:"endpoint"`
+	P256dh      string    `json:"p256dh"`
+	Auth        string    `json:"auth"`
+	UserAgent   string    `json:"user_agent,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastUpdated time.Time `json:"last_updated"`
+}
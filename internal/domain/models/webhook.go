@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/corechain/notification-service/pkg/constants"
+)
+
+type WebhookDeliveryStatus = constants.WebhookDeliveryStatus
+
+// WebhookSubscription is an endpoint that receives a copy of every sent
+// notification matching its project and notification type. Empty filters
+// match everything.
+type WebhookSubscription struct {
+	ID                  string     `json:"id"`
+	URL                 string     `json:"url"`
+	Secret              string     `json:"secret,omitempty"`
+	Description         string     `json:"description,omitempty"`
+	ProjectID           string     `json:"project_id,omitempty"`
+	NotificationType    string     `json:"notification_type,omitempty"`
+	IsActive            bool       `json:"is_active"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	DisabledReason      string     `json:"disabled_reason,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// WebhookDelivery is one notification queued for one webhook subscription
+type WebhookDelivery struct {
+	ID             string                `json:"id"`
+	SubscriptionID string                `json:"subscription_id"`
+	NotificationID string                `json:"notification_id"`
+	Payload        string                `json:"-"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	AttemptCount   int                   `json:"attempt_count"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at"`
+	LastStatusCode int                   `json:"last_status_code,omitempty"`
+	LastError      string                `json:"last_error,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+}
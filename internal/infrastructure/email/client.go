@@ -0,0 +1,218 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/corechain/notification-service/internal/domain/interfaces"
+	"github.com/corechain/notification-service/internal/utils/errors"
+)
+
+// TLS modes of the SMTP connection
+const (
+	TLSModeNone     = "none"
+	TLSModeStartTLS = "starttls"
+	TLSModeTLS      = "tls"
+)
+
+type Config struct {
+	Host        string
+	Port        int
+	Username    string
+	Password    string
+	FromAddress string
+	FromName    string
+	TLSMode     string
+	Timeout     time.Duration
+}
+
+// Client sends emails over SMTP, opening one connection per email
+type Client struct {
+	config Config
+	from   mail.Address
+}
+
+func NewClient(config Config) *Client {
+	return &Client{
+		config: config,
+		from:   mail.Address{Name: config.FromName, Address: config.FromAddress},
+	}
+}
+
+func (c *Client) Send(ctx context.Context, message interfaces.EmailMessage) (string, error) {
+	messageID := c.newMessageID()
+	content, err := c.buildMessage(message, messageID)
+	if err != nil {
+		return "", errors.NewEmailErrorWithCode(errors.ErrCodeEmail, "failed to build email", err)
+	}
+
+	client, err := c.connect(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	if err := client.Mail(c.config.FromAddress); err != nil {
+		return "", wrapSMTPError("sender rejected", err)
+	}
+	if err := client.Rcpt(message.To); err != nil {
+		return "", wrapSMTPError(fmt.Sprintf("recipient %s rejected", message.To), err)
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return "", wrapSMTPError("failed to start email data", err)
+	}
+	if _, err := writer.Write(content); err != nil {
+		return "", wrapSMTPError("failed to write email data", err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", wrapSMTPError(fmt.Sprintf("email to %s rejected", message.To), err)
+	}
+
+	// The email is accepted once DATA completes; a failing QUIT changes nothing
+	_ = client.Quit()
+
+	return messageID, nil
+}
+
+// connect dials the SMTP server, secures the connection as configured and
+// authenticates when credentials are set
+func (c *Client) connect(ctx context.Context) (*smtp.Client, error) {
+	deadline := time.Now().Add(c.config.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	addr := net.JoinHostPort(c.config.Host, strconv.Itoa(c.config.Port))
+	dialer := &net.Dialer{Deadline: deadline}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, errors.NewEmailErrorWithCode(errors.ErrCodeEmailUnavailable, fmt.Sprintf("failed to connect to SMTP server %s", addr), err)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return nil, errors.NewEmailErrorWithCode(errors.ErrCodeEmailUnavailable, "failed to set SMTP deadline", err)
+	}
+
+	tlsConfig := &tls.Config{ServerName: c.config.Host}
+	if c.config.TLSMode == TLSModeTLS {
+		conn = tls.Client(conn, tlsConfig)
+	}
+
+	client, err := smtp.NewClient(conn, c.config.Host)
+	if err != nil {
+		conn.Close()
+		return nil, wrapSMTPError(fmt.Sprintf("failed to greet SMTP server %s", addr), err)
+	}
+
+	if c.config.TLSMode == TLSModeStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			client.Close()
+			return nil, errors.NewEmailErrorWithCode(errors.ErrCodeEmail, fmt.Sprintf("SMTP server %s does not support STARTTLS", addr), nil)
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, wrapSMTPError("STARTTLS failed", err)
+		}
+	}
+
+	if c.config.Username != "" {
+		auth := smtp.PlainAuth("", c.config.Username, c.config.Password, c.config.Host)
+		if err := client.Auth(auth); err != nil {
+			client.Close()
+			return nil, errors.NewEmailErrorWithCode(errors.ErrCodeEmail, "SMTP authentication failed", err)
+		}
+	}
+
+	return client, nil
+}
+
+// buildMessage renders a multipart/alternative message with the plain-text
+// body first, so clients that cannot show HTML fall back to it
+func (c *Client) buildMessage(message interfaces.EmailMessage, messageID string) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	alternatives := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=UTF-8", message.TextBody},
+		{"text/html; charset=UTF-8", message.HTMLBody},
+	}
+	for _, alternative := range alternatives {
+		part, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {alternative.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		encoder := quotedprintable.NewWriter(part)
+		if _, err := encoder.Write([]byte(alternative.content)); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	to := mail.Address{Address: message.To}
+	headers := []string{
+		"From: " + c.from.String(),
+		"To: " + to.String(),
+		"Subject: " + mime.QEncoding.Encode("UTF-8", message.Subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"Message-ID: " + messageID,
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/alternative; boundary=" + parts.Boundary(),
+	}
+
+	var content bytes.Buffer
+	content.WriteString(strings.Join(headers, "\r\n"))
+	content.WriteString("\r\n\r\n")
+	content.Write(body.Bytes())
+
+	return content.Bytes(), nil
+}
+
+// newMessageID returns a unique Message-ID in the domain of the sender
+func (c *Client) newMessageID() string {
+	random := make([]byte, 16)
+	_, _ = rand.Read(random)
+
+	domain := "localhost"
+	if at := strings.LastIndex(c.config.FromAddress, "@"); at >= 0 {
+		domain = c.config.FromAddress[at+1:]
+	}
+
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(random), domain)
+}
+
+// wrapSMTPError classifies an SMTP failure. Permanent 5xx replies mean the
+// email bounced and will never be accepted; 4xx replies and connection
+// problems are worth retrying.
+func wrapSMTPError(message string, err error) error {
+	if protoErr, ok := err.(*textproto.Error); ok && protoErr.Code >= 500 {
+		return errors.NewEmailErrorWithCode(errors.ErrCodeEmailRejected, message, err)
+	}
+	return errors.NewEmailErrorWithCode(errors.ErrCodeEmailUnavailable, message, err)
+}
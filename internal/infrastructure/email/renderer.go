@@ -0,0 +1,112 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/pkg/constants"
+)
+
+// defaultTemplate renders notification types without templates of their own
+const defaultTemplate = "default"
+
+//go:embed templates
+var templateFiles embed.FS
+
+var templateFuncs = map[string]interface{}{
+	"priority": priorityText,
+}
+
+// Renderer renders the HTML and plain-text bodies of notification emails.
+// Templates are named after the notification type, e.g. task_overdue.html and
+// task_overdue.txt; HTML templates define the "content" block of layout.html.
+type Renderer struct {
+	html map[string]*htmltemplate.Template
+	text map[string]*texttemplate.Template
+}
+
+func NewRenderer() (*Renderer, error) {
+	r := &Renderer{
+		html: make(map[string]*htmltemplate.Template),
+		text: make(map[string]*texttemplate.Template),
+	}
+
+	entries, err := fs.ReadDir(templateFiles, "templates")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list email templates: %w", err)
+	}
+
+	for _, entry := range entries {
+		file := path.Join("templates", entry.Name())
+		name := strings.TrimSuffix(entry.Name(), path.Ext(entry.Name()))
+
+		switch path.Ext(entry.Name()) {
+		case ".html":
+			if name == "layout" {
+				continue
+			}
+			tmpl, err := htmltemplate.New("layout.html").Funcs(templateFuncs).ParseFS(templateFiles, "templates/layout.html", file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse email template %s: %w", file, err)
+			}
+			r.html[name] = tmpl
+		case ".txt":
+			tmpl, err := texttemplate.New(entry.Name()).Funcs(templateFuncs).ParseFS(templateFiles, file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse email template %s: %w", file, err)
+			}
+			r.text[name] = tmpl
+		}
+	}
+
+	if r.html[defaultTemplate] == nil || r.text[defaultTemplate] == nil {
+		return nil, fmt.Errorf("default email templates are missing")
+	}
+
+	return r, nil
+}
+
+// Render returns the HTML and plain-text bodies for a notification, using the
+// default templates when its type has none of its own
+func (r *Renderer) Render(notification *models.Notification) (string, string, error) {
+	name := string(notification.NotificationType)
+
+	htmlTemplate, ok := r.html[name]
+	if !ok {
+		htmlTemplate = r.html[defaultTemplate]
+	}
+	textTemplate, ok := r.text[name]
+	if !ok {
+		textTemplate = r.text[defaultTemplate]
+	}
+
+	var html, text bytes.Buffer
+	if err := htmlTemplate.Execute(&html, notification); err != nil {
+		return "", "", fmt.Errorf("failed to render HTML email for %s: %w", name, err)
+	}
+	if err := textTemplate.Execute(&text, notification); err != nil {
+		return "", "", fmt.Errorf("failed to render text email for %s: %w", name, err)
+	}
+
+	return html.String(), text.String(), nil
+}
+
+func priorityText(priority int) string {
+	switch priority {
+	case constants.PriorityHigh:
+		return "High"
+	case constants.PriorityMedium:
+		return "Medium"
+	case constants.PriorityLow:
+		return "Low"
+	default:
+		return ""
+	}
+}
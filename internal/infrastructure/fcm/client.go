@@ -3,14 +3,19 @@ package fcm
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	firebase "firebase.google.com/go/v4"
+	"firebase.google.com/go/v4/errorutils"
 	"firebase.google.com/go/v4/messaging"
 	"github.com/corechain/notification-service/internal/domain/interfaces"
 	"github.com/corechain/notification-service/internal/utils/errors"
 	"google.golang.org/api/option"
 )
 
+// maxMessagesPerRequest is the FCM limit for SendEach and SendEachForMulticast
+const maxMessagesPerRequest = 500
+
 type Client struct {
 	messagingClient *messaging.Client
 }
@@ -37,89 +42,217 @@ func NewClient(ctx context.Context, credentialsPath string, projectID string) (*
 	}, nil
 }
 
-func (c *Client) SendNotification(ctx context.Context, token string, title string, body string, data map[string]string) error {
+func (c *Client) SendNotification(ctx context.Context, token string, title string, body string, data map[string]string) (string, error) {
 	message := &messaging.Message{
 		Token: token,
 		Notification: &messaging.Notification{
 			Title: title,
 			Body:  body,
 		},
-		Data: data,
-		Android: &messaging.AndroidConfig{
-			Priority: "high",
-			Notification: &messaging.AndroidNotification{
-				Sound:     "default",
-				ChannelID: "task_notifications",
+		Data:    data,
+		Android: androidConfig(interfaces.FCMSendOptions{}),
+		APNS:    apnsConfig(interfaces.FCMSendOptions{}),
+	}
+
+	messageID, err := c.messagingClient.Send(ctx, message)
+	if err != nil {
+		return "", wrapSendError(fmt.Sprintf("failed to send FCM notification to token %s", token), err)
+	}
+
+	return messageID, nil
+}
+
+func (c *Client) SendMulticast(ctx context.Context, tokens []string, title string, body string, data map[string]string, options interfaces.FCMSendOptions) ([]interfaces.FCMSendResult, error) {
+	results := make([]interfaces.FCMSendResult, 0, len(tokens))
+
+	for start := 0; start < len(tokens); start += maxMessagesPerRequest {
+		end := min(start+maxMessagesPerRequest, len(tokens))
+
+		message := &messaging.MulticastMessage{
+			Tokens: tokens[start:end],
+			Notification: &messaging.Notification{
+				Title: title,
+				Body:  body,
 			},
-		},
-		APNS: &messaging.APNSConfig{
-			Payload: &messaging.APNSPayload{
-				Aps: &messaging.Aps{
-					Sound: "default",
-					Badge: intPtr(1),
+			Data:    data,
+			Android: androidConfig(options),
+			APNS:    apnsConfig(options),
+		}
+
+		batchResponse, err := c.messagingClient.SendEachForMulticast(ctx, message)
+		if err != nil {
+			return nil, wrapSendError("failed to send multicast notification", err)
+		}
+
+		results = append(results, sendResults(tokens[start:end], batchResponse)...)
+	}
+
+	return results, nil
+}
+
+func (c *Client) SendBatchNotifications(ctx context.Context, notifications []interfaces.FCMMessage) ([]interfaces.FCMSendResult, error) {
+	results := make([]interfaces.FCMSendResult, 0, len(notifications))
+
+	for start := 0; start < len(notifications); start += maxMessagesPerRequest {
+		end := min(start+maxMessagesPerRequest, len(notifications))
+
+		tokens := make([]string, 0, end-start)
+		messages := make([]*messaging.Message, 0, end-start)
+		for _, notif := range notifications[start:end] {
+			tokens = append(tokens, notif.Token)
+			messages = append(messages, &messaging.Message{
+				Token: notif.Token,
+				Notification: &messaging.Notification{
+					Title: notif.Title,
+					Body:  notif.Body,
 				},
-			},
+				Data:    notif.Data,
+				Android: androidConfig(notif.Options),
+				APNS:    apnsConfig(notif.Options),
+			})
+		}
+
+		batchResponse, err := c.messagingClient.SendEach(ctx, messages)
+		if err != nil {
+			return nil, wrapSendError("failed to send batch notifications", err)
+		}
+
+		results = append(results, sendResults(tokens, batchResponse)...)
+	}
+
+	return results, nil
+}
+
+func (c *Client) SendToAudience(ctx context.Context, topic string, condition string, title string, body string, data map[string]string) (string, error) {
+	message := &messaging.Message{
+		Topic:     topic,
+		Condition: condition,
+		Notification: &messaging.Notification{
+			Title: title,
+			Body:  body,
 		},
+		Data:    data,
+		Android: androidConfig(interfaces.FCMSendOptions{}),
+		APNS:    apnsConfig(interfaces.FCMSendOptions{}),
+	}
+	if topic != "" {
+		message.Condition = ""
 	}
 
-	response, err := c.messagingClient.Send(ctx, message)
+	messageID, err := c.messagingClient.Send(ctx, message)
 	if err != nil {
-		return errors.NewFCMError(fmt.Sprintf("failed to send FCM notification to token %s", token), err)
+		return "", wrapSendError(fmt.Sprintf("failed to send FCM notification to audience %s%s", topic, condition), err)
 	}
 
-	_ = response // Response contains message ID
+	return messageID, nil
+}
+
+func (c *Client) SubscribeToTopic(ctx context.Context, tokens []string, topic string) error {
+	response, err := c.messagingClient.SubscribeToTopic(ctx, tokens, topic)
+	if err != nil {
+		return wrapSendError(fmt.Sprintf("failed to subscribe tokens to topic %s", topic), err)
+	}
+	return topicManagementError("subscribe", topic, len(tokens), response)
+}
 
-	return nil
+func (c *Client) UnsubscribeFromTopic(ctx context.Context, tokens []string, topic string) error {
+	response, err := c.messagingClient.UnsubscribeFromTopic(ctx, tokens, topic)
+	if err != nil {
+		return wrapSendError(fmt.Sprintf("failed to unsubscribe tokens from topic %s", topic), err)
+	}
+	return topicManagementError("unsubscribe", topic, len(tokens), response)
 }
 
-func (c *Client) SendBatchNotifications(ctx context.Context, notifications []interfaces.FCMMessage) error {
-	if len(notifications) == 0 {
+// topicManagementError reports the tokens FCM refused to (un)subscribe
+func topicManagementError(operation string, topic string, total int, response *messaging.TopicManagementResponse) error {
+	if response.FailureCount == 0 {
 		return nil
 	}
 
-	messages := make([]*messaging.Message, len(notifications))
-	for i, notif := range notifications {
-		messages[i] = &messaging.Message{
-			Token: notif.Token,
-			Notification: &messaging.Notification{
-				Title: notif.Title,
-				Body:  notif.Body,
-			},
-			Data: notif.Data,
-			Android: &messaging.AndroidConfig{
-				Priority: "high",
-				Notification: &messaging.AndroidNotification{
-					Sound:     "default",
-					ChannelID: "task_notifications",
-				},
-			},
-			APNS: &messaging.APNSConfig{
-				Payload: &messaging.APNSPayload{
-					Aps: &messaging.Aps{
-						Sound: "default",
-						Badge: intPtr(1),
-					},
-				},
-			},
+	reason := ""
+	if len(response.Errors) > 0 {
+		reason = response.Errors[0].Reason
+	}
+
+	return errors.NewFCMError(
+		fmt.Sprintf("failed to %s %d out of %d tokens for topic %s: %s", operation, response.FailureCount, total, topic, reason),
+		nil,
+	)
+}
+
+// sendResults pairs the per-message responses of a batch with their tokens
+func sendResults(tokens []string, batchResponse *messaging.BatchResponse) []interfaces.FCMSendResult {
+	results := make([]interfaces.FCMSendResult, len(tokens))
+	for i, token := range tokens {
+		results[i].Token = token
+		if i >= len(batchResponse.Responses) {
+			results[i].Err = errors.NewFCMError("missing response for token", nil)
+			continue
+		}
+
+		response := batchResponse.Responses[i]
+		if response.Success {
+			results[i].MessageID = response.MessageID
+		} else {
+			results[i].Err = wrapSendError(fmt.Sprintf("failed to send FCM notification to token %s", token), response.Error)
 		}
 	}
+	return results
+}
 
-	batchResponse, err := c.messagingClient.SendAll(ctx, messages)
-	if err != nil {
-		return errors.NewFCMError("failed to send batch notifications", err)
+func androidConfig(options interfaces.FCMSendOptions) *messaging.AndroidConfig {
+	return &messaging.AndroidConfig{
+		Priority:    "high",
+		CollapseKey: options.CollapseKey,
+		Notification: &messaging.AndroidNotification{
+			Sound:     "default",
+			ChannelID: "task_notifications",
+			Tag:       options.CollapseKey,
+		},
 	}
+}
 
-	// Check for failures
-	if batchResponse.FailureCount > 0 {
-		return errors.NewFCMError(
-			fmt.Sprintf("failed to send %d out of %d notifications", batchResponse.FailureCount, len(notifications)),
-			nil,
-		)
+func apnsConfig(options interfaces.FCMSendOptions) *messaging.APNSConfig {
+	config := &messaging.APNSConfig{
+		Payload: &messaging.APNSPayload{
+			Aps: &messaging.Aps{
+				Sound: "default",
+				Badge: options.Badge,
+			},
+		},
+	}
+	if options.CollapseKey != "" {
+		config.Headers = map[string]string{"apns-collapse-id": options.CollapseKey}
 	}
+	return config
+}
 
-	return nil
+// wrapSendError sorts a messaging error into one of the typed FCM errors.
+// Outages and quota errors are transient, dead tokens and auth problems are not.
+// An invalid argument only means a dead token when FCM blames the registration
+// token; otherwise the message itself is malformed.
+func wrapSendError(message string, err error) *errors.AppError {
+	switch {
+	case messaging.IsUnregistered(err):
+		return errors.NewFCMErrorWithCode(errors.ErrCodeFCMUnregistered, message, err)
+	case messaging.IsSenderIDMismatch(err), messaging.IsInvalidArgument(err) && blamesToken(err):
+		return errors.NewFCMErrorWithCode(errors.ErrCodeFCMInvalidToken, message, err)
+	case messaging.IsInvalidArgument(err):
+		return errors.NewFCMErrorWithCode(errors.ErrCodeFCM, message, err)
+	case messaging.IsQuotaExceeded(err):
+		return errors.NewFCMErrorWithCode(errors.ErrCodeFCMQuota, message, err)
+	case messaging.IsUnavailable(err), messaging.IsInternal(err):
+		return errors.NewFCMErrorWithCode(errors.ErrCodeFCMUnavailable, message, err)
+	case messaging.IsThirdPartyAuthError(err), errorutils.IsUnauthenticated(err), errorutils.IsPermissionDenied(err):
+		return errors.NewFCMErrorWithCode(errors.ErrCodeFCMAuth, message, err)
+	default:
+		return errors.NewFCMError(message, err)
+	}
 }
 
-func intPtr(i int) *int {
-	return &i
+// blamesToken reports whether FCM rejected a request because of its
+// registration token, as in "The registration token is not a valid FCM
+// registration token"
+func blamesToken(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "registration token")
 }
@@ -2,7 +2,10 @@ package fcm
 
 import (
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/corechain/notification-service/pkg/constants"
 )
 
 // Template represents a notification template
@@ -48,7 +51,108 @@ func BuildTaskUpdatedNotification(taskTitle string, updatedByName string) Templa
 	}
 }
 
-// BuildNewMessageNotification creates a notification for new messages (future)
+// BuildTaskDeletedNotification creates a notification for the assignee of a deleted task
+func BuildTaskDeletedNotification(taskTitle string, deletedByName string) Template {
+	title := "Task Deleted"
+	body := fmt.Sprintf("%s has been deleted", taskTitle)
+
+	if deletedByName != "" {
+		body = fmt.Sprintf("%s deleted: %s", deletedByName, taskTitle)
+	}
+
+	return Template{
+		Title: title,
+		Body:  body,
+	}
+}
+
+// BuildTaskAssignedToYouNotification creates a notification for the new assignee of a reassigned task
+func BuildTaskAssignedToYouNotification(taskTitle string, reassignedByName string) Template {
+	title := "Task Reassigned to You"
+	body := fmt.Sprintf("You are now assigned to: %s", taskTitle)
+
+	if reassignedByName != "" {
+		body = fmt.Sprintf("%s reassigned a task to you: %s", reassignedByName, taskTitle)
+	}
+
+	return Template{
+		Title: title,
+		Body:  body,
+	}
+}
+
+// BuildTaskReassignedAwayNotification creates a notification for the previous assignee of a reassigned task
+func BuildTaskReassignedAwayNotification(taskTitle string, newAssigneeName string) Template {
+	title := "Task Reassigned"
+	body := fmt.Sprintf("%s is no longer assigned to you", taskTitle)
+
+	if newAssigneeName != "" {
+		body = fmt.Sprintf("%s has been reassigned to %s", taskTitle, newAssigneeName)
+	}
+
+	return Template{
+		Title: title,
+		Body:  body,
+	}
+}
+
+// BuildTaskStatusChangedNotification creates a notification for task status changes
+func BuildTaskStatusChangedNotification(taskTitle string, previousStatus *int, status int, changedByName string) Template {
+	title := "Task Status Changed"
+	body := fmt.Sprintf("%s is now %s", taskTitle, getTaskStatusText(status))
+
+	if previousStatus != nil {
+		body = fmt.Sprintf("%s moved from %s to %s", taskTitle, getTaskStatusText(*previousStatus), getTaskStatusText(status))
+	}
+
+	if changedByName != "" {
+		body = fmt.Sprintf("%s: %s", changedByName, body)
+	}
+
+	return Template{
+		Title: title,
+		Body:  body,
+	}
+}
+
+// BuildTaskCompletedNotification creates a notification for the creator of a completed task
+func BuildTaskCompletedNotification(taskTitle string, completedByName string) Template {
+	title := "Task Completed"
+	body := fmt.Sprintf("%s has been completed", taskTitle)
+
+	if completedByName != "" {
+		body = fmt.Sprintf("%s completed: %s", completedByName, taskTitle)
+	}
+
+	return Template{
+		Title: title,
+		Body:  body,
+	}
+}
+
+// BuildTaskDueSoonNotification creates a reminder for a task that is due within the given window
+func BuildTaskDueSoonNotification(taskTitle string, dueDate time.Time, within string) Template {
+	title := fmt.Sprintf("Task Due in %s", within)
+	body := fmt.Sprintf("%s is due %s", taskTitle, dueDate.Format("Jan 02 15:04"))
+
+	return Template{
+		Title: title,
+		Body:  body,
+	}
+}
+
+// BuildTaskOverdueNotification creates a reminder for a task past its due date
+func BuildTaskOverdueNotification(taskTitle string, dueDate time.Time) Template {
+	title := "Task Overdue"
+	body := fmt.Sprintf("%s was due %s", taskTitle, dueDate.Format("Jan 02 15:04"))
+
+	return Template{
+		Title: title,
+		Body:  body,
+	}
+}
+
+// BuildNewMessageNotification creates a notification for new messages
 func BuildNewMessageNotification(senderName string, messagePreview string) Template {
 	title := fmt.Sprintf("New message from %s", senderName)
 	body := messagePreview
@@ -63,7 +167,7 @@ func BuildNewMessageNotification(senderName string, messagePreview string) Templ
 	}
 }
 
-// BuildIncomingCallNotification creates a notification for incoming calls (future)
+// BuildIncomingCallNotification creates a notification for incoming calls
 func BuildIncomingCallNotification(callerName string, callType string) Template {
 	title := "Incoming Call"
 	body := fmt.Sprintf("%s is calling (%s)", callerName, callType)
@@ -74,6 +178,43 @@ func BuildIncomingCallNotification(callerName string, callType string) Template
 	}
 }
 
+// BuildDigestNotification summarises the task notifications collected in a
+// digest, e.g. "5 new tasks in Website Redesign"
+func BuildDigestNotification(created int, updated int, projectName string, taskTitles []string) Template {
+	var counts []string
+	if created > 0 {
+		counts = append(counts, plural(created, "new task", "new tasks"))
+	}
+	if updated > 0 {
+		counts = append(counts, plural(updated, "task update", "task updates"))
+	}
+
+	title := strings.Join(counts, " and ")
+	if projectName != "" {
+		title = fmt.Sprintf("%s in %s", title, projectName)
+	}
+
+	body := "Open your notifications to see them"
+	const shown = 3
+	if len(taskTitles) > shown {
+		body = fmt.Sprintf("%s and %d more", strings.Join(taskTitles[:shown], ", "), len(taskTitles)-shown)
+	} else if len(taskTitles) > 0 {
+		body = strings.Join(taskTitles, ", ")
+	}
+
+	return Template{
+		Title: title,
+		Body:  body,
+	}
+}
+
+func plural(count int, singular string, plural string) string {
+	if count == 1 {
+		return "1 " + singular
+	}
+	return fmt.Sprintf("%d %s", count, plural)
+}
+
 func getPriorityText(priority int) string {
 	switch priority {
 	case 1:
@@ -86,3 +227,16 @@ func getPriorityText(priority int) string {
 		return ""
 	}
 }
+
+func getTaskStatusText(status int) string {
+	switch status {
+	case constants.TaskStatusPending:
+		return "Pending"
+	case constants.TaskStatusInProgress:
+		return "In Progress"
+	case constants.TaskStatusCompleted:
+		return "Completed"
+	default:
+		return "Unknown"
+	}
+}
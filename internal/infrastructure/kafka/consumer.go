@@ -4,59 +4,108 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/corechain/notification-service/internal/domain/interfaces"
 	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/idempotency"
 	"github.com/corechain/notification-service/internal/utils/logger"
 	kafkago "github.com/segmentio/kafka-go"
 	"go.uber.org/zap"
 )
 
+// Headers attached to retried and dead-lettered messages in addition to the original ones
+const (
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderErrorCode         = "x-error-code"
+	HeaderErrorMessage      = "x-error-message"
+	HeaderAttempts          = "x-attempts"
+	HeaderFailedAt          = "x-failed-at"
+)
+
 type Consumer struct {
-	readers  map[string]*kafkago.Reader
-	handlers map[string]interfaces.MessageHandler
-	wg       sync.WaitGroup
-	ctx      context.Context
-	cancel   context.CancelFunc
-	mu       sync.RWMutex
+	readers    map[string]*kafkago.Reader
+	routes     map[string]route
+	handlers   map[string]interfaces.MessageHandler
+	producer   *Producer
+	deadLetter DeadLetterConfig
+	retryTiers []RetryTier
+	wg         sync.WaitGroup
+	ctx        context.Context
+	cancel     context.CancelFunc
+	mu         sync.RWMutex
 }
 
 type ConsumerConfig struct {
-	Brokers []string
-	GroupID string
-	Topics  []string
+	Brokers    []string
+	GroupID    string
+	Topics     []string
+	Producer   *Producer
+	DeadLetter DeadLetterConfig
+	// RetryTiers are the delayed retry topics used for transient failures,
+	// ordered from the shortest to the longest delay
+	RetryTiers []RetryTier
+}
+
+// DeadLetterConfig controls how messages that keep failing are parked.
+// Topics maps a source topic to its dead-letter topic; source topics without
+// an explicit entry use the source topic name followed by TopicSuffix.
+type DeadLetterConfig struct {
+	Enabled      bool
+	TopicSuffix  string
+	Topics       map[string]string
+	MaxAttempts  int
+	RetryBackoff time.Duration
 }
 
 func NewConsumer(config ConsumerConfig) *Consumer {
 	ctx, cancel := context.WithCancel(context.Background())
 	
 	consumer := &Consumer{
-		readers:  make(map[string]*kafkago.Reader),
-		handlers: make(map[string]interfaces.MessageHandler),
-		ctx:      ctx,
-		cancel:   cancel,
+		readers:    make(map[string]*kafkago.Reader),
+		routes:     make(map[string]route),
+		handlers:   make(map[string]interfaces.MessageHandler),
+		producer:   config.Producer,
+		deadLetter: config.DeadLetter,
+		retryTiers: config.RetryTiers,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+
+	if consumer.deadLetter.MaxAttempts <= 0 {
+		consumer.deadLetter.MaxAttempts = 1
 	}
 
 	for _, topic := range config.Topics {
-		reader := kafkago.NewReader(kafkago.ReaderConfig{
-			Brokers:        config.Brokers,
-			GroupID:        config.GroupID,
-			Topic:          topic,
-			MinBytes:       10e3,
-			MaxBytes:       10e6,
-			CommitInterval: 0, // Manual commit for reliability
-		})
-		consumer.readers[topic] = reader
+		consumer.addReader(config, topic, route{source: topic, tier: -1})
+		for i, tier := range consumer.retryTiers {
+			consumer.addReader(config, RetryTopic(topic, tier), route{source: topic, tier: i})
+		}
 	}
 
 	return consumer
 }
 
+func (c *Consumer) addReader(config ConsumerConfig, topic string, r route) {
+	reader := kafkago.NewReader(kafkago.ReaderConfig{
+		Brokers:        config.Brokers,
+		GroupID:        config.GroupID,
+		Topic:          topic,
+		MinBytes:       10e3,
+		MaxBytes:       10e6,
+		CommitInterval: 0, // Manual commit for reliability
+	})
+	c.readers[topic] = reader
+	c.routes[topic] = r
+}
+
 func (c *Consumer) RegisterHandler(topic string, handler interfaces.MessageHandler) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if _, exists := c.readers[topic]; !exists {
+	if r, exists := c.routes[topic]; !exists || r.isRetry() {
 		return errors.NewKafkaError(fmt.Sprintf("no reader registered for topic: %s", topic), nil)
 	}
 
@@ -71,14 +120,15 @@ func (c *Consumer) Start(ctx context.Context) error {
 	defer c.mu.RUnlock()
 
 	for topic, reader := range c.readers {
-		handler, exists := c.handlers[topic]
+		r := c.routes[topic]
+		handler, exists := c.handlers[r.source]
 		if !exists {
 			logger.Warn("No handler registered for topic, skipping", zap.String("topic", topic))
 			continue
 		}
 
 		c.wg.Add(1)
-		go c.consumeTopic(ctx, topic, reader, handler)
+		go c.consumeTopic(ctx, topic, r, reader, handler)
 		logger.Info("Started consuming topic", zap.String("topic", topic))
 	}
 
@@ -86,7 +136,7 @@ func (c *Consumer) Start(ctx context.Context) error {
 	return nil
 }
 
-func (c *Consumer) consumeTopic(ctx context.Context, topic string, reader *kafkago.Reader, handler interfaces.MessageHandler) {
+func (c *Consumer) consumeTopic(ctx context.Context, topic string, r route, reader *kafkago.Reader, handler interfaces.MessageHandler) {
 	defer c.wg.Done()
 
 	for {
@@ -113,12 +163,13 @@ func (c *Consumer) consumeTopic(ctx context.Context, topic string, reader *kafka
 				zap.Int64("offset", message.Offset),
 			)
 
-			if err := handler(ctx, message.Value); err != nil {
-				logger.Error("Error processing message",
-					zap.String("topic", topic),
-					zap.Error(err),
-					zap.ByteString("message", message.Value),
-				)
+			if r.isRetry() {
+				if err := waitUntilDue(ctx, topic, message); err != nil {
+					return
+				}
+			}
+
+			if err := c.handleMessage(ctx, topic, r, message, handler); err != nil {
 				// Don't commit on error - ensures message is reprocessed
 				continue
 			}
@@ -137,6 +188,120 @@ func (c *Consumer) consumeTopic(ctx context.Context, topic string, reader *kafka
 	}
 }
 
+// handleMessage runs the handler for a message. Transient failures are handed
+// to the next retry tier (or retried in-process when no tiers are configured);
+// permanent failures and messages that exhausted every tier are published to
+// the dead-letter topic. Either way the message is reported as handled so its
+// offset gets committed. The handler finds the event's idempotency key in its
+// context, so an event delivered again is not turned into notifications twice.
+func (c *Consumer) handleMessage(ctx context.Context, topic string, r route, message kafkago.Message, handler interfaces.MessageHandler) error {
+	ctx = idempotency.WithKey(ctx, eventKey(message))
+
+	maxLocalAttempts := c.deadLetter.MaxAttempts
+	if len(c.retryTiers) > 0 {
+		maxLocalAttempts = 1
+	}
+
+	var err error
+	attempts := previousAttempts(message)
+
+	for localAttempts := 1; ; localAttempts++ {
+		attempts++
+
+		if err = handler(ctx, message.Value); err == nil {
+			return nil
+		}
+
+		if localAttempts >= maxLocalAttempts || !errors.IsTransient(err) {
+			break
+		}
+
+		logger.Warn("Error processing message, retrying",
+			zap.String("topic", topic),
+			zap.Int("attempt", attempts),
+			zap.Error(err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.deadLetter.RetryBackoff * time.Duration(localAttempts)):
+		}
+	}
+
+	logger.Error("Error processing message",
+		zap.String("topic", topic),
+		zap.Int("attempts", attempts),
+		zap.Bool("transient", errors.IsTransient(err)),
+		zap.Error(err),
+		zap.ByteString("message", message.Value),
+	)
+
+	if errors.IsTransient(err) {
+		if tier, ok := c.nextRetryTier(r); ok {
+			if retryErr := c.publishRetry(ctx, r, tier, message, err, attempts); retryErr != nil {
+				logger.Error("Error publishing message to retry topic",
+					zap.String("topic", topic),
+					zap.String("retry_topic", RetryTopic(r.source, tier)),
+					zap.Error(retryErr),
+				)
+				return retryErr
+			}
+
+			logger.Warn("Message scheduled for retry",
+				zap.String("topic", topic),
+				zap.String("retry_topic", RetryTopic(r.source, tier)),
+				zap.Duration("delay", tier.Delay),
+				zap.Int("attempts", attempts),
+			)
+			return nil
+		}
+	}
+
+	deadLetterTopic := c.deadLetterTopic(r.source)
+	if deadLetterTopic == "" {
+		return err
+	}
+
+	if dlqErr := c.publishDeadLetter(ctx, deadLetterTopic, message, err, attempts); dlqErr != nil {
+		logger.Error("Error publishing message to dead-letter topic",
+			zap.String("topic", topic),
+			zap.String("dead_letter_topic", deadLetterTopic),
+			zap.Error(dlqErr),
+		)
+		return dlqErr
+	}
+
+	logger.Warn("Message moved to dead-letter topic",
+		zap.String("topic", topic),
+		zap.String("dead_letter_topic", deadLetterTopic),
+		zap.Int64("offset", message.Offset),
+		zap.String("error_code", errors.CodeOf(err)),
+	)
+
+	return nil
+}
+
+func (c *Consumer) deadLetterTopic(topic string) string {
+	if !c.deadLetter.Enabled || c.producer == nil {
+		return ""
+	}
+	if dlq, ok := c.deadLetter.Topics[topic]; ok {
+		return dlq
+	}
+	if c.deadLetter.TopicSuffix == "" {
+		return ""
+	}
+	return topic + c.deadLetter.TopicSuffix
+}
+
+func (c *Consumer) publishDeadLetter(ctx context.Context, deadLetterTopic string, message kafkago.Message, cause error, attempts int) error {
+	headers := failureHeaders(message, cause, attempts)
+	headers = setHeader(headers, HeaderFailedAt, time.Now().UTC().Format(time.RFC3339))
+
+	return c.producer.Publish(ctx, deadLetterTopic, message.Key, message.Value, headers)
+}
+
 // Stop gracefully stops consuming messages
 func (c *Consumer) Stop() error {
 	logger.Info("Stopping Kafka consumer...")
@@ -0,0 +1,44 @@
+package kafka
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// maxEventIDLength is the longest event_id stored as is; longer ones are hashed
+const maxEventIDLength = 200
+
+// eventKey identifies the event in a message across redeliveries: its
+// event_id when the producer set one, otherwise its position in the source
+// topic. Messages on retry topics carry that position in their origin headers.
+func eventKey(message kafkago.Message) string {
+	var envelope struct {
+		EventID string `json:"event_id"`
+	}
+	if err := json.Unmarshal(message.Value, &envelope); err == nil && envelope.EventID != "" {
+		if len(envelope.EventID) > maxEventIDLength {
+			return "event:" + hashKey(envelope.EventID)
+		}
+		return "event:" + envelope.EventID
+	}
+
+	topic := message.Topic
+	partition := strconv.Itoa(message.Partition)
+	offset := strconv.FormatInt(message.Offset, 10)
+	if originalTopic, ok := headerValue(message.Headers, HeaderOriginalTopic); ok {
+		topic = originalTopic
+		partition, _ = headerValue(message.Headers, HeaderOriginalPartition)
+		offset, _ = headerValue(message.Headers, HeaderOriginalOffset)
+	}
+
+	return "offset:" + hashKey(topic+"/"+partition+"/"+offset)
+}
+
+func hashKey(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
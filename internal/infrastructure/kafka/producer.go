@@ -0,0 +1,70 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	kafkago "github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// Producer publishes messages to arbitrary Kafka topics. The topic is chosen
+// per message so a single producer can serve dead-letter and retry topics.
+type Producer struct {
+	writer *kafkago.Writer
+}
+
+type ProducerConfig struct {
+	Brokers []string
+}
+
+func NewProducer(config ProducerConfig) *Producer {
+	return &Producer{
+		writer: &kafkago.Writer{
+			Addr:                   kafkago.TCP(config.Brokers...),
+			Balancer:               &kafkago.Hash{},
+			RequiredAcks:           kafkago.RequireAll,
+			AllowAutoTopicCreation: true,
+			BatchTimeout:           10 * time.Millisecond,
+		},
+	}
+}
+
+// Publish writes a single message to the given topic and waits for the broker acknowledgement
+func (p *Producer) Publish(ctx context.Context, topic string, key []byte, value []byte, headers []kafkago.Header) error {
+	message := kafkago.Message{
+		Topic:   topic,
+		Key:     key,
+		Value:   value,
+		Headers: headers,
+	}
+
+	if err := p.writer.WriteMessages(ctx, message); err != nil {
+		return errors.NewKafkaError(fmt.Sprintf("failed to publish message to topic: %s", topic), err)
+	}
+
+	logger.Debug("Published message",
+		zap.String("topic", topic),
+		zap.Int("message_size", len(value)),
+	)
+
+	return nil
+}
+
+// PublishJSON encodes payload as JSON and publishes it keyed by key
+func (p *Producer) PublishJSON(ctx context.Context, topic string, key string, payload interface{}) error {
+	value, err := json.Marshal(payload)
+	if err != nil {
+		return errors.NewKafkaError(fmt.Sprintf("failed to encode message for topic: %s", topic), err)
+	}
+
+	return p.Publish(ctx, topic, []byte(key), value, nil)
+}
+
+func (p *Producer) Close() error {
+	return p.writer.Close()
+}
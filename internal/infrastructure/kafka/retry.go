@@ -0,0 +1,150 @@
+package kafka
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	kafkago "github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// HeaderRetryNotBefore holds the RFC 3339 time before which a message on a
+// retry topic must not be redelivered to its handler
+const HeaderRetryNotBefore = "x-retry-not-before"
+
+// RetryTier is a delayed retry topic. A message that fails transiently on its
+// source topic is re-published to <topic>.retry.<Label> and handled again once
+// Delay has passed; failing again moves it to the next tier.
+type RetryTier struct {
+	Label string
+	Delay time.Duration
+}
+
+// RetryTopic returns the name of the retry topic for a source topic and tier
+func RetryTopic(topic string, tier RetryTier) string {
+	return topic + ".retry." + tier.Label
+}
+
+// route ties a reader topic back to the source topic whose handler processes
+// it. Tier is the index of the retry tier, or -1 for the source topic itself.
+type route struct {
+	source string
+	tier   int
+}
+
+func (r route) isRetry() bool {
+	return r.tier >= 0
+}
+
+// nextRetryTier returns the tier a transiently failing message on this route
+// should move to, or false when all tiers are exhausted
+func (c *Consumer) nextRetryTier(r route) (RetryTier, bool) {
+	next := r.tier + 1
+	if next >= len(c.retryTiers) || c.producer == nil {
+		return RetryTier{}, false
+	}
+	return c.retryTiers[next], true
+}
+
+func (c *Consumer) publishRetry(ctx context.Context, r route, tier RetryTier, message kafkago.Message, cause error, attempts int) error {
+	notBefore := time.Now().Add(tier.Delay).UTC()
+	headers := failureHeaders(message, cause, attempts)
+	headers = setHeader(headers, HeaderRetryNotBefore, notBefore.Format(time.RFC3339Nano))
+
+	return c.producer.Publish(ctx, RetryTopic(r.source, tier), message.Key, message.Value, headers)
+}
+
+// waitUntilDue blocks until the message's retry delay has passed. Messages on
+// a tier share the same delay, so they become due in the order they arrive.
+func waitUntilDue(ctx context.Context, topic string, message kafkago.Message) error {
+	value, ok := headerValue(message.Headers, HeaderRetryNotBefore)
+	if !ok {
+		return nil
+	}
+
+	notBefore, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		logger.Warn("Ignoring malformed retry header",
+			zap.String("topic", topic),
+			zap.String("value", value),
+		)
+		return nil
+	}
+
+	wait := time.Until(notBefore)
+	if wait <= 0 {
+		return nil
+	}
+
+	logger.Debug("Delaying retry message",
+		zap.String("topic", topic),
+		zap.Int64("offset", message.Offset),
+		zap.Duration("wait", wait),
+	)
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// failureHeaders copies the message headers and records the failure. The
+// origin headers are only set once so they keep pointing at the source topic
+// while a message moves through the retry tiers.
+func failureHeaders(message kafkago.Message, cause error, attempts int) []kafkago.Header {
+	headers := make([]kafkago.Header, len(message.Headers), len(message.Headers)+8)
+	copy(headers, message.Headers)
+
+	if _, ok := headerValue(headers, HeaderOriginalTopic); !ok {
+		headers = setHeader(headers, HeaderOriginalTopic, message.Topic)
+		headers = setHeader(headers, HeaderOriginalPartition, strconv.Itoa(message.Partition))
+		headers = setHeader(headers, HeaderOriginalOffset, strconv.FormatInt(message.Offset, 10))
+	}
+
+	headers = setHeader(headers, HeaderErrorCode, errors.CodeOf(cause))
+	headers = setHeader(headers, HeaderErrorMessage, cause.Error())
+	headers = setHeader(headers, HeaderAttempts, strconv.Itoa(attempts))
+
+	return headers
+}
+
+// previousAttempts returns how many times the message was already handled
+// before it was re-published to a retry topic
+func previousAttempts(message kafkago.Message) int {
+	value, ok := headerValue(message.Headers, HeaderAttempts)
+	if !ok {
+		return 0
+	}
+	attempts, err := strconv.Atoi(value)
+	if err != nil || attempts < 0 {
+		return 0
+	}
+	return attempts
+}
+
+func headerValue(headers []kafkago.Header, key string) (string, bool) {
+	for _, header := range headers {
+		if header.Key == key {
+			return string(header.Value), true
+		}
+	}
+	return "", false
+}
+
+func setHeader(headers []kafkago.Header, key string, value string) []kafkago.Header {
+	for i, header := range headers {
+		if header.Key == key {
+			headers[i].Value = []byte(value)
+			return headers
+		}
+	}
+	return append(headers, kafkago.Header{Key: key, Value: []byte(value)})
+}
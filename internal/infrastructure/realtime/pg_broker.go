@@ -0,0 +1,109 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/corechain/notification-service/internal/domain/interfaces"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/internal/utils/logger"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// PGBroker fans realtime events out across replicas with Postgres
+// LISTEN/NOTIFY. Events are published through the shared connection pool and
+// received on one dedicated connection per replica.
+type PGBroker struct {
+	db      *gorm.DB
+	dsn     string
+	channel string
+}
+
+func NewPGBroker(db *gorm.DB, dsn string, channel string) *PGBroker {
+	return &PGBroker{
+		db:      db,
+		dsn:     dsn,
+		channel: channel,
+	}
+}
+
+func (b *PGBroker) Publish(ctx context.Context, event interfaces.RealtimeEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return errors.NewAppError(errors.ErrCodeInternal, "failed to marshal realtime event", err)
+	}
+
+	if err := b.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", b.channel, string(payload)).Error; err != nil {
+		return errors.NewDatabaseError("failed to publish realtime event", err)
+	}
+
+	return nil
+}
+
+func (b *PGBroker) Listen(ctx context.Context, handle func(interfaces.RealtimeEvent)) {
+	delay := minReconnectDelay
+	for ctx.Err() == nil {
+		connected, err := b.listen(ctx, handle)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			delay = minReconnectDelay
+		}
+
+		logger.Warn("Realtime listener disconnected, reconnecting",
+			zap.Error(err),
+			zap.String("channel", b.channel),
+			zap.Duration("delay", delay),
+		)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
+
+// listen holds one LISTEN connection until it fails. It reports whether the
+// connection was established, so repeated failures to connect back off.
+func (b *PGBroker) listen(ctx context.Context, handle func(interfaces.RealtimeEvent)) (bool, error) {
+	conn, err := pgx.Connect(ctx, b.dsn)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{b.channel}.Sanitize()); err != nil {
+		return false, err
+	}
+
+	logger.Info("Listening for realtime events", zap.String("channel", b.channel))
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, err
+		}
+
+		var event interfaces.RealtimeEvent
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			logger.Warn("Ignoring malformed realtime event",
+				zap.Error(err),
+				zap.String("payload", notification.Payload),
+			)
+			continue
+		}
+
+		handle(event)
+	}
+}
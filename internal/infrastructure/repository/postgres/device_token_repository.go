@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DeviceTokenEntity struct {
+	ID          string    `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID      string    `gorm:"column:user_id;type:varchar(100);not null;uniqueIndex:uq_fcm_tokens_user_device"`
+	DeviceID    string    `gorm:"column:device_id;type:varchar(100);not null;uniqueIndex:uq_fcm_tokens_user_device"`
+	FCMToken    string    `gorm:"column:fcm_token;type:text;not null;uniqueIndex:uq_fcm_tokens_token"`
+	Platform    string    `gorm:"column:platform;type:varchar(20)"`
+	AppVersion  string    `gorm:"column:app_version;type:varchar(50)"`
+	IsActive    bool      `gorm:"column:is_active;not null;default:true"`
+	CreatedAt   time.Time `gorm:"column:created_at;not null;default:now()"`
+	LastUpdated time.Time `gorm:"column:last_updated;not null;default:now()"`
+}
+
+func (DeviceTokenEntity) TableName() string {
+	return "user_fcm_tokens"
+}
+
+type DeviceTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewDeviceTokenRepository(db *gorm.DB) *DeviceTokenRepository {
+	return &DeviceTokenRepository{db: db}
+}
+
+func (r *DeviceTokenRepository) Upsert(ctx context.Context, device *models.DeviceToken) error {
+	now := time.Now()
+	entity := r.toEntity(device)
+	entity.IsActive = true
+	entity.CreatedAt = now
+	entity.LastUpdated = now
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// A token identifies one app installation; drop it from any other device first
+		if err := tx.Where("fcm_token = ? AND NOT (user_id = ? AND device_id = ?)", entity.FCMToken, entity.UserID, entity.DeviceID).
+			Delete(&DeviceTokenEntity{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "device_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"fcm_token", "platform", "app_version", "is_active", "last_updated"}),
+		}).Create(entity).Error
+	})
+	if err != nil {
+		return errors.NewDatabaseError("failed to register device token", err)
+	}
+
+	// Reload so the caller sees the stored ID and creation time of an existing device
+	var stored DeviceTokenEntity
+	if err := r.db.WithContext(ctx).First(&stored, "user_id = ? AND device_id = ?", entity.UserID, entity.DeviceID).Error; err != nil {
+		return errors.NewDatabaseError("failed to load device token", err)
+	}
+	*device = *r.toModel(&stored)
+
+	return nil
+}
+
+func (r *DeviceTokenRepository) Refresh(ctx context.Context, userID string, deviceID string, fcmToken string, appVersion string) (*models.DeviceToken, error) {
+	var entity DeviceTokenEntity
+	if err := r.db.WithContext(ctx).First(&entity, "user_id = ? AND device_id = ?", userID, deviceID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewAppError(errors.ErrCodeNotFound, "device not found", err)
+		}
+		return nil, errors.NewDatabaseError("failed to get device token", err)
+	}
+
+	device := r.toModel(&entity)
+	device.FCMToken = fcmToken
+	if appVersion != "" {
+		device.AppVersion = appVersion
+	}
+
+	if err := r.Upsert(ctx, device); err != nil {
+		return nil, err
+	}
+
+	return device, nil
+}
+
+func (r *DeviceTokenRepository) Delete(ctx context.Context, userID string, deviceID string) (*models.DeviceToken, error) {
+	var entities []DeviceTokenEntity
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Returning{}).
+		Where("user_id = ? AND device_id = ?", userID, deviceID).
+		Delete(&entities)
+
+	if result.Error != nil {
+		return nil, errors.NewDatabaseError("failed to delete device token", result.Error)
+	}
+
+	if len(entities) == 0 {
+		return nil, errors.NewAppError(errors.ErrCodeNotFound, "device not found", nil)
+	}
+
+	return r.toModel(&entities[0]), nil
+}
+
+func (r *DeviceTokenRepository) GetByUserID(ctx context.Context, userID string) ([]*models.DeviceToken, error) {
+	return r.find(ctx, r.db.WithContext(ctx).Where("user_id = ?", userID))
+}
+
+func (r *DeviceTokenRepository) GetActiveByUserID(ctx context.Context, userID string) ([]*models.DeviceToken, error) {
+	return r.find(ctx, r.db.WithContext(ctx).Where("user_id = ? AND is_active", userID))
+}
+
+func (r *DeviceTokenRepository) DeactivateToken(ctx context.Context, fcmToken string) (*models.DeviceToken, error) {
+	var entities []DeviceTokenEntity
+
+	result := r.db.WithContext(ctx).Model(&entities).
+		Clauses(clause.Returning{}).
+		Where("fcm_token = ? AND is_active", fcmToken).
+		Updates(map[string]interface{}{
+			"is_active":    false,
+			"last_updated": time.Now(),
+		})
+	if result.Error != nil {
+		return nil, errors.NewDatabaseError("failed to deactivate device token", result.Error)
+	}
+
+	if len(entities) == 0 {
+		return nil, nil
+	}
+
+	return r.toModel(&entities[0]), nil
+}
+
+func (r *DeviceTokenRepository) find(ctx context.Context, query *gorm.DB) ([]*models.DeviceToken, error) {
+	var entities []DeviceTokenEntity
+
+	if err := query.Order("last_updated DESC").Find(&entities).Error; err != nil {
+		return nil, errors.NewDatabaseError("failed to get device tokens", err)
+	}
+
+	devices := make([]*models.DeviceToken, 0, len(entities))
+	for i := range entities {
+		devices = append(devices, r.toModel(&entities[i]))
+	}
+
+	return devices, nil
+}
+
+func (r *DeviceTokenRepository) toEntity(device *models.DeviceToken) *DeviceTokenEntity {
+	return &DeviceTokenEntity{
+		ID:          device.ID,
+		UserID:      device.UserID,
+		DeviceID:    device.DeviceID,
+		FCMToken:    device.FCMToken,
+		Platform:    device.Platform,
+		AppVersion:  device.AppVersion,
+		IsActive:    device.IsActive,
+		CreatedAt:   device.CreatedAt,
+		LastUpdated: device.LastUpdated,
+	}
+}
+
+func (r *DeviceTokenRepository) toModel(entity *DeviceTokenEntity) *models.DeviceToken {
+	return &models.DeviceToken{
+		ID:          entity.ID,
+		UserID:      entity.UserID,
+		DeviceID:    entity.DeviceID,
+		FCMToken:    entity.FCMToken,
+		Platform:    entity.Platform,
+		AppVersion:  entity.AppVersion,
+		IsActive:    entity.IsActive,
+		CreatedAt:   entity.CreatedAt,
+		LastUpdated: entity.LastUpdated,
+	}
+}
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"gorm.io/gorm"
+)
+
+type NotificationAttemptEntity struct {
+	ID                string    `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	NotificationID    string    `gorm:"column:notification_id;type:uuid;not null;index"`
+	AttemptNumber     int       `gorm:"column:attempt_number;not null"`
+	Provider          string    `gorm:"column:provider;type:varchar(20);not null"`
+	ProviderMessageID string    `gorm:"column:provider_message_id;type:text"`
+	Status            string    `gorm:"column:status;type:varchar(20);not null"`
+	ErrorCode         string    `gorm:"column:error_code;type:varchar(50)"`
+	ErrorMessage      string    `gorm:"column:error_message;type:text"`
+	LatencyMs         int64     `gorm:"column:latency_ms;not null;default:0"`
+	CreatedAt         time.Time `gorm:"column:created_at;not null;default:now()"`
+}
+
+func (NotificationAttemptEntity) TableName() string {
+	return "notification_attempts"
+}
+
+type NotificationAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationAttemptRepository(db *gorm.DB) *NotificationAttemptRepository {
+	return &NotificationAttemptRepository{db: db}
+}
+
+func (r *NotificationAttemptRepository) Create(ctx context.Context, attempt *models.NotificationAttempt) error {
+	entity := &NotificationAttemptEntity{
+		ID:                attempt.ID,
+		NotificationID:    attempt.NotificationID,
+		AttemptNumber:     attempt.AttemptNumber,
+		Provider:          attempt.Provider,
+		ProviderMessageID: attempt.ProviderMessageID,
+		Status:            string(attempt.Status),
+		ErrorCode:         attempt.ErrorCode,
+		ErrorMessage:      attempt.ErrorMessage,
+		LatencyMs:         attempt.LatencyMs,
+		CreatedAt:         attempt.CreatedAt,
+	}
+
+	if err := r.db.WithContext(ctx).Create(entity).Error; err != nil {
+		return errors.NewDatabaseError("failed to create notification attempt", err)
+	}
+
+	attempt.ID = entity.ID
+	return nil
+}
+
+func (r *NotificationAttemptRepository) GetByNotificationID(ctx context.Context, notificationID string) ([]*models.NotificationAttempt, error) {
+	var entities []NotificationAttemptEntity
+
+	query := r.db.WithContext(ctx).
+		Where("notification_id = ?", notificationID).
+		Order("attempt_number ASC, created_at ASC")
+
+	if err := query.Find(&entities).Error; err != nil {
+		return nil, errors.NewDatabaseError("failed to get notification attempts", err)
+	}
+
+	attempts := make([]*models.NotificationAttempt, 0, len(entities))
+	for _, entity := range entities {
+		attempts = append(attempts, &models.NotificationAttempt{
+			ID:                entity.ID,
+			NotificationID:    entity.NotificationID,
+			AttemptNumber:     entity.AttemptNumber,
+			Provider:          entity.Provider,
+			ProviderMessageID: entity.ProviderMessageID,
+			Status:            models.AttemptStatus(entity.Status),
+			ErrorCode:         entity.ErrorCode,
+			ErrorMessage:      entity.ErrorMessage,
+			LatencyMs:         entity.LatencyMs,
+			CreatedAt:         entity.CreatedAt,
+		})
+	}
+
+	return attempts, nil
+}
@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/pkg/constants"
+	"gorm.io/gorm"
+)
+
+type NotificationDigestEntity struct {
+	ID             string     `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID         string     `gorm:"column:user_id;type:varchar(100);not null"`
+	ProjectID      string     `gorm:"column:project_id;type:varchar(100);not null"`
+	Status         string     `gorm:"column:status;type:varchar(20);not null;default:open"`
+	ItemCount      int        `gorm:"column:item_count;not null;default:0"`
+	SendAt         time.Time  `gorm:"column:send_at;not null"`
+	NotificationID *string    `gorm:"column:notification_id;type:uuid"`
+	CreatedAt      time.Time  `gorm:"column:created_at;not null;default:now()"`
+	SentAt         *time.Time `gorm:"column:sent_at"`
+}
+
+func (NotificationDigestEntity) TableName() string {
+	return "notification_digests"
+}
+
+type NotificationDigestRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationDigestRepository(db *gorm.DB) *NotificationDigestRepository {
+	return &NotificationDigestRepository{db: db}
+}
+
+func (r *NotificationDigestRepository) AddItem(ctx context.Context, notification *models.Notification, sendAt time.Time) (*models.NotificationDigest, error) {
+	var entity NotificationDigestEntity
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The upsert locks the open digest until the item is linked, so a
+		// replica claiming it meanwhile skips it rather than sending it short
+		err := tx.Raw(`
+			INSERT INTO notification_digests (user_id, project_id, status, item_count, send_at)
+			VALUES (?, ?, ?, 1, ?)
+			ON CONFLICT (user_id, project_id) WHERE status = 'open'
+			DO UPDATE SET item_count = notification_digests.item_count + 1
+			RETURNING *`,
+			notification.UserID, notification.ProjectID, string(constants.DigestStatusOpen), sendAt,
+		).Scan(&entity).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&NotificationEntity{}).
+			Where("id = ?", notification.ID).
+			Updates(map[string]interface{}{
+				"status":        string(constants.StatusDigested),
+				"digest_id":     entity.ID,
+				"next_retry_at": nil,
+			}).Error
+	})
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to add notification to digest", err)
+	}
+
+	return r.toModel(&entity), nil
+}
+
+func (r *NotificationDigestRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.NotificationDigest, error) {
+	var entities []NotificationDigestEntity
+
+	query := r.db.WithContext(ctx).Raw(`
+		UPDATE notification_digests SET status = ?, send_at = ?
+		WHERE id IN (
+			SELECT id FROM notification_digests
+			WHERE status IN (?, ?) AND send_at <= ?
+			ORDER BY send_at ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		string(constants.DigestStatusSending), now.Add(lease),
+		string(constants.DigestStatusOpen), string(constants.DigestStatusSending), now,
+		limit,
+	)
+
+	if err := query.Scan(&entities).Error; err != nil {
+		return nil, errors.NewDatabaseError("failed to claim notification digests", err)
+	}
+
+	digests := make([]*models.NotificationDigest, 0, len(entities))
+	for i := range entities {
+		digests = append(digests, r.toModel(&entities[i]))
+	}
+
+	return digests, nil
+}
+
+func (r *NotificationDigestRepository) MarkSent(ctx context.Context, id string, notificationID string) error {
+	updates := map[string]interface{}{
+		"status":  string(constants.DigestStatusSent),
+		"sent_at": time.Now(),
+	}
+	if notificationID != "" {
+		updates["notification_id"] = notificationID
+	}
+
+	err := r.db.WithContext(ctx).Model(&NotificationDigestEntity{}).
+		Where("id = ?", id).
+		Updates(updates).Error
+	if err != nil {
+		return errors.NewDatabaseError("failed to mark notification digest as sent", err)
+	}
+
+	return nil
+}
+
+func (r *NotificationDigestRepository) GetByID(ctx context.Context, id string) (*models.NotificationDigest, error) {
+	var entity NotificationDigestEntity
+
+	if err := r.db.WithContext(ctx).First(&entity, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewAppError(errors.ErrCodeNotFound, "notification digest not found", err)
+		}
+		return nil, errors.NewDatabaseError("failed to get notification digest", err)
+	}
+
+	return r.toModel(&entity), nil
+}
+
+func (r *NotificationDigestRepository) toModel(entity *NotificationDigestEntity) *models.NotificationDigest {
+	digest := &models.NotificationDigest{
+		ID:        entity.ID,
+		UserID:    entity.UserID,
+		ProjectID: entity.ProjectID,
+		Status:    models.DigestStatus(entity.Status),
+		ItemCount: entity.ItemCount,
+		SendAt:    entity.SendAt,
+		CreatedAt: entity.CreatedAt,
+		SentAt:    entity.SentAt,
+	}
+	if entity.NotificationID != nil {
+		digest.NotificationID = *entity.NotificationID
+	}
+	return digest
+}
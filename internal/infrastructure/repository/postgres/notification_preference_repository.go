@@ -0,0 +1,247 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationPreferenceEntity struct {
+	UserID            string     `gorm:"primaryKey;column:user_id;type:varchar(100)"`
+	Channels          *string    `gorm:"column:channels;type:jsonb"`
+	Muted             bool       `gorm:"column:muted;not null;default:false"`
+	Digest            bool       `gorm:"column:digest;not null;default:false"`
+	QuietHoursStart   string     `gorm:"column:quiet_hours_start;type:varchar(5);not null"`
+	QuietHoursEnd     string     `gorm:"column:quiet_hours_end;type:varchar(5);not null"`
+	TimeZone          string     `gorm:"column:time_zone;type:varchar(64);not null"`
+	DoNotDisturbUntil *time.Time `gorm:"column:do_not_disturb_until"`
+	CreatedAt         time.Time  `gorm:"column:created_at;not null;default:now()"`
+	UpdatedAt         time.Time  `gorm:"column:updated_at;not null;default:now()"`
+}
+
+func (NotificationPreferenceEntity) TableName() string {
+	return "notification_preferences"
+}
+
+type PreferenceOverrideEntity struct {
+	ID               string  `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID           string  `gorm:"column:user_id;type:varchar(100);not null"`
+	NotificationType string  `gorm:"column:notification_type;type:varchar(50);not null"`
+	ProjectID        string  `gorm:"column:project_id;type:varchar(100);not null"`
+	Channels         *string `gorm:"column:channels;type:jsonb"`
+	Muted            *bool   `gorm:"column:muted"`
+}
+
+func (PreferenceOverrideEntity) TableName() string {
+	return "notification_preference_overrides"
+}
+
+type NotificationPreferenceRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationPreferenceRepository(db *gorm.DB) *NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{db: db}
+}
+
+func (r *NotificationPreferenceRepository) GetByUserID(ctx context.Context, userID string) (*models.NotificationPreferences, error) {
+	var entities []NotificationPreferenceEntity
+
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Limit(1).Find(&entities).Error; err != nil {
+		return nil, errors.NewDatabaseError("failed to get notification preferences", err)
+	}
+
+	if len(entities) == 0 {
+		return &models.NotificationPreferences{
+			UserID:    userID,
+			Overrides: []models.PreferenceOverride{},
+		}, nil
+	}
+
+	var overrides []PreferenceOverrideEntity
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("notification_type, project_id").
+		Find(&overrides).Error
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to get notification preference overrides", err)
+	}
+
+	return r.toModel(&entities[0], overrides)
+}
+
+// Replace upserts the user's defaults and swaps their overrides in one
+// transaction. Do-not-disturb is kept; it has its own setter.
+func (r *NotificationPreferenceRepository) Replace(ctx context.Context, preferences *models.NotificationPreferences) error {
+	entity, overrides, err := r.toEntities(preferences)
+	if err != nil {
+		return errors.NewDatabaseError("failed to encode notification preferences", err)
+	}
+
+	now := time.Now()
+	entity.CreatedAt = now
+	entity.UpdatedAt = now
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(
+			clause.OnConflict{
+				Columns:   []clause.Column{{Name: "user_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"channels", "muted", "digest", "quiet_hours_start", "quiet_hours_end", "time_zone", "updated_at"}),
+			},
+			clause.Returning{},
+		).Create(entity).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", preferences.UserID).Delete(&PreferenceOverrideEntity{}).Error; err != nil {
+			return err
+		}
+
+		if len(overrides) == 0 {
+			return nil
+		}
+		return tx.Create(&overrides).Error
+	})
+	if err != nil {
+		return errors.NewDatabaseError("failed to store notification preferences", err)
+	}
+
+	preferences.DoNotDisturbUntil = entity.DoNotDisturbUntil
+	preferences.UpdatedAt = &now
+	return nil
+}
+
+func (r *NotificationPreferenceRepository) SetDoNotDisturb(ctx context.Context, userID string, until *time.Time) error {
+	now := time.Now()
+	entity := &NotificationPreferenceEntity{
+		UserID:            userID,
+		DoNotDisturbUntil: until,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"do_not_disturb_until", "updated_at"}),
+	}).Create(entity).Error
+	if err != nil {
+		return errors.NewDatabaseError("failed to set do not disturb", err)
+	}
+
+	return nil
+}
+
+// Delete resets a user to the default preferences; the overrides go with
+// the row they reference
+func (r *NotificationPreferenceRepository) Delete(ctx context.Context, userID string) error {
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&NotificationPreferenceEntity{}).Error; err != nil {
+		return errors.NewDatabaseError("failed to delete notification preferences", err)
+	}
+	return nil
+}
+
+func (r *NotificationPreferenceRepository) toEntities(preferences *models.NotificationPreferences) (*NotificationPreferenceEntity, []*PreferenceOverrideEntity, error) {
+	channels, err := encodeChannels(preferences.Channels)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entity := &NotificationPreferenceEntity{
+		UserID:   preferences.UserID,
+		Channels: channels,
+		Muted:    preferences.Muted,
+		Digest:   preferences.Digest,
+	}
+	if preferences.QuietHours != nil {
+		entity.QuietHoursStart = preferences.QuietHours.Start
+		entity.QuietHoursEnd = preferences.QuietHours.End
+		entity.TimeZone = preferences.QuietHours.TimeZone
+	}
+
+	overrides := make([]*PreferenceOverrideEntity, 0, len(preferences.Overrides))
+	for _, override := range preferences.Overrides {
+		channels, err := encodeChannels(override.Channels)
+		if err != nil {
+			return nil, nil, err
+		}
+		overrides = append(overrides, &PreferenceOverrideEntity{
+			UserID:           preferences.UserID,
+			NotificationType: string(override.NotificationType),
+			ProjectID:        override.ProjectID,
+			Channels:         channels,
+			Muted:            override.Muted,
+		})
+	}
+
+	return entity, overrides, nil
+}
+
+func (r *NotificationPreferenceRepository) toModel(entity *NotificationPreferenceEntity, overrides []PreferenceOverrideEntity) (*models.NotificationPreferences, error) {
+	updatedAt := entity.UpdatedAt
+	preferences := &models.NotificationPreferences{
+		UserID:            entity.UserID,
+		Muted:             entity.Muted,
+		Digest:            entity.Digest,
+		Overrides:         make([]models.PreferenceOverride, 0, len(overrides)),
+		DoNotDisturbUntil: entity.DoNotDisturbUntil,
+		UpdatedAt:         &updatedAt,
+	}
+	if entity.QuietHoursStart != "" {
+		preferences.QuietHours = &models.QuietHours{
+			Start:    entity.QuietHoursStart,
+			End:      entity.QuietHoursEnd,
+			TimeZone: entity.TimeZone,
+		}
+	}
+
+	channels, err := decodeChannels(entity.Channels)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to unmarshal preferred channels", err)
+	}
+	preferences.Channels = channels
+
+	for i := range overrides {
+		channels, err := decodeChannels(overrides[i].Channels)
+		if err != nil {
+			return nil, errors.NewDatabaseError("failed to unmarshal preferred channels", err)
+		}
+		preferences.Overrides = append(preferences.Overrides, models.PreferenceOverride{
+			NotificationType: models.NotificationType(overrides[i].NotificationType),
+			ProjectID:        overrides[i].ProjectID,
+			Channels:         channels,
+			Muted:            overrides[i].Muted,
+		})
+	}
+
+	return preferences, nil
+}
+
+// encodeChannels keeps nil (inherit) apart from an empty list (no channel)
+func encodeChannels(channels []string) (*string, error) {
+	if channels == nil {
+		return nil, nil
+	}
+	channelsJSON, err := json.Marshal(channels)
+	if err != nil {
+		return nil, err
+	}
+	encoded := string(channelsJSON)
+	return &encoded, nil
+}
+
+func decodeChannels(encoded *string) ([]string, error) {
+	if encoded == nil {
+		return nil, nil
+	}
+	channels := []string{}
+	if err := json.Unmarshal([]byte(*encoded), &channels); err != nil {
+		return nil, err
+	}
+	return channels, nil
+}
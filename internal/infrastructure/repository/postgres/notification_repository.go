@@ -7,8 +7,10 @@ import (
 
 	"github.com/corechain/notification-service/internal/domain/models"
 	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/pkg/constants"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type NotificationEntity struct {
@@ -16,6 +18,7 @@ type NotificationEntity struct {
 	NotificationType string    `gorm:"column:notification_type;type:varchar(50);not null"`
 	UserID           string    `gorm:"column:user_id;type:varchar(100);not null;index"`
 	FCMToken         string    `gorm:"column:fcm_token;type:text;not null"`
+	RecipientEmail   string    `gorm:"column:recipient_email;type:varchar(255)"`
 	Title            string    `gorm:"column:title;type:varchar(255);not null"`
 	Body             string    `gorm:"column:body;type:text;not null"`
 	Data             string    `gorm:"column:data;type:jsonb"`
@@ -23,10 +26,23 @@ type NotificationEntity struct {
 	ErrorMessage     string    `gorm:"column:error_message;type:text"`
 	CreatedAt        time.Time `gorm:"column:created_at;not null;default:now();index:idx_notifications_created_at,sort:desc"`
 	SentAt           *time.Time `gorm:"column:sent_at"`
+	SendAt           *time.Time `gorm:"column:send_at"`
+	ClaimedUntil     *time.Time `gorm:"column:claimed_until"`
 	RetryCount       int       `gorm:"column:retry_count;default:0"`
+	NextRetryAt      *time.Time `gorm:"column:next_retry_at"`
+	ReadAt           *time.Time `gorm:"column:read_at"`
+	SeenAt           *time.Time `gorm:"column:seen_at"`
 	TaskID           string    `gorm:"column:task_id;type:varchar(100);index"`
 	ProjectID        string    `gorm:"column:project_id;type:varchar(100)"`
 	Priority         int       `gorm:"column:priority"`
+	DigestID         *string   `gorm:"column:digest_id;type:uuid"`
+	CoalesceKey      string    `gorm:"column:coalesce_key;type:varchar(255);not null"`
+	IdempotencyKey   string    `gorm:"column:idempotency_key;type:varchar(255);not null"`
+	Channels         string    `gorm:"column:channels;type:jsonb;default:null"`
+	Deliveries       string    `gorm:"column:deliveries;type:jsonb;default:null"`
+	TargetTopic      string    `gorm:"column:target_topic;type:varchar(255)"`
+	TargetCondition  string    `gorm:"column:target_condition;type:text"`
+	RecipientCount   int       `gorm:"column:recipient_count"`
 }
 
 func (NotificationEntity) TableName() string {
@@ -49,14 +65,94 @@ func NewNotificationRepository(dsn string) (*NotificationRepository, error) {
 func (r *NotificationRepository) Create(ctx context.Context, notification *models.Notification) error {
 	entity := r.toEntity(notification)
 	
-	if err := r.db.WithContext(ctx).Create(entity).Error; err != nil {
-		return errors.NewDatabaseError("failed to create notification", err)
+	db := r.db.WithContext(ctx)
+	if entity.IdempotencyKey != "" {
+		db = db.Clauses(clause.OnConflict{
+			Columns:     idempotencyColumns,
+			TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "idempotency_key <> ''"}}},
+			DoNothing:   true,
+		})
+	}
+
+	result := db.Create(entity)
+	if result.Error != nil {
+		return errors.NewDatabaseError("failed to create notification", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errDuplicateNotification()
 	}
 
 	notification.ID = entity.ID
 	return nil
 }
 
+// idempotencyColumns make up the unique index that keeps an event from
+// creating the same notification twice
+var idempotencyColumns = []clause.Column{{Name: "idempotency_key"}, {Name: "user_id"}, {Name: "notification_type"}}
+
+func errDuplicateNotification() error {
+	return errors.NewAppError(errors.ErrCodeDuplicate, "notification already created for this event", nil)
+}
+
+func (r *NotificationRepository) CreateCoalesced(ctx context.Context, notification *models.Notification, sendAt time.Time) (int64, error) {
+	entity := r.toEntity(notification)
+	entity.Status = string(constants.StatusScheduled)
+
+	var superseded []struct {
+		SendAt *time.Time
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Check before superseding, or a redelivered update would replace
+		// the newer one waiting in its place
+		if entity.IdempotencyKey != "" {
+			var existing int64
+			err := tx.Model(&NotificationEntity{}).
+				Where("idempotency_key = ? AND user_id = ? AND notification_type = ?", entity.IdempotencyKey, entity.UserID, entity.NotificationType).
+				Count(&existing).Error
+			if err != nil {
+				return err
+			}
+			if existing > 0 {
+				return errDuplicateNotification()
+			}
+		}
+
+		err := tx.Raw(`
+			UPDATE notifications SET status = ?
+			WHERE coalesce_key = ? AND status = ?
+			RETURNING send_at`,
+			string(constants.StatusSuperseded),
+			notification.CoalesceKey, string(constants.StatusScheduled),
+		).Scan(&superseded).Error
+		if err != nil {
+			return err
+		}
+
+		// The window opens with the first update, so a steady stream of
+		// updates can't hold the notification back for good
+		entity.SendAt = &sendAt
+		for _, previous := range superseded {
+			if previous.SendAt != nil && previous.SendAt.Before(*entity.SendAt) {
+				entity.SendAt = previous.SendAt
+			}
+		}
+
+		return tx.Create(entity).Error
+	})
+	if errors.CodeOf(err) == errors.ErrCodeDuplicate {
+		return 0, err
+	}
+	if err != nil {
+		return 0, errors.NewDatabaseError("failed to create coalesced notification", err)
+	}
+
+	notification.ID = entity.ID
+	notification.Status = constants.StatusScheduled
+	notification.SendAt = entity.SendAt
+	return int64(len(superseded)), nil
+}
+
 func (r *NotificationRepository) Update(ctx context.Context, notification *models.Notification) error {
 	entity := r.toEntity(notification)
 	
@@ -80,16 +176,40 @@ func (r *NotificationRepository) GetByID(ctx context.Context, id string) (*model
 	return r.toModel(&entity)
 }
 
-func (r *NotificationRepository) GetByUserID(ctx context.Context, userID string, limit, offset int) ([]*models.Notification, error) {
+func (r *NotificationRepository) GetByIdempotencyKey(ctx context.Context, key string, userID string, notificationType models.NotificationType) (*models.Notification, error) {
+	var entity NotificationEntity
+
+	err := r.db.WithContext(ctx).
+		Where("idempotency_key = ? AND user_id = ? AND notification_type = ?", key, userID, string(notificationType)).
+		First(&entity).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewAppError(errors.ErrCodeNotFound, "notification not found", err)
+		}
+		return nil, errors.NewDatabaseError("failed to get notification by idempotency key", err)
+	}
+
+	return r.toModel(&entity)
+}
+
+func (r *NotificationRepository) GetByUserID(ctx context.Context, userID string, query models.NotificationQuery) ([]*models.Notification, error) {
 	var entities []NotificationEntity
-	
-	query := r.db.WithContext(ctx).
-		Where("user_id = ?", userID).
-		Order("created_at DESC").
-		Limit(limit).
-		Offset(offset)
 
-	if err := query.Find(&entities).Error; err != nil {
+	direction, comparison := "DESC", "<"
+	if query.Ascending {
+		direction, comparison = "ASC", ">"
+	}
+
+	db := applyFilter(r.db.WithContext(ctx).Where("user_id = ?", userID), query.Filter)
+	if query.After != nil {
+		db = db.Where("(created_at, id) "+comparison+" (?, ?)", query.After.CreatedAt, query.After.ID)
+	}
+	db = db.Order("created_at " + direction + ", id " + direction).Limit(query.Limit)
+	if query.Offset > 0 {
+		db = db.Offset(query.Offset)
+	}
+
+	if err := db.Find(&entities).Error; err != nil {
 		return nil, errors.NewDatabaseError("failed to get notifications by user ID", err)
 	}
 
@@ -105,6 +225,80 @@ func (r *NotificationRepository) GetByUserID(ctx context.Context, userID string,
 	return notifications, nil
 }
 
+func (r *NotificationRepository) CountByUserID(ctx context.Context, userID string, filter models.NotificationFilter) (int64, error) {
+	var total int64
+
+	query := applyFilter(r.db.WithContext(ctx).Model(&NotificationEntity{}).Where("user_id = ?", userID), filter)
+	if err := query.Count(&total).Error; err != nil {
+		return 0, errors.NewDatabaseError("failed to count notifications by user ID", err)
+	}
+
+	return total, nil
+}
+
+func (r *NotificationRepository) MarkAnnounced(ctx context.Context, id string) error {
+	err := r.db.WithContext(ctx).Model(&NotificationEntity{}).
+		Where("id = ?", id).
+		Update("stream_seq", gorm.Expr("nextval('notification_stream_seq')")).Error
+	if err != nil {
+		return errors.NewDatabaseError("failed to mark notification as announced", err)
+	}
+
+	return nil
+}
+
+func (r *NotificationRepository) GetAnnouncedAfter(ctx context.Context, userID string, afterID string, limit int) ([]*models.Notification, error) {
+	var entities []NotificationEntity
+
+	query := r.db.WithContext(ctx).Raw(`
+		SELECT n.* FROM notifications n
+		JOIN notifications after ON after.id = ? AND after.user_id = n.user_id
+		WHERE n.user_id = ? AND n.status IN ?
+			AND n.stream_seq > after.stream_seq
+		ORDER BY n.stream_seq ASC
+		LIMIT ?`,
+		afterID, userID, inboxStatuses, limit,
+	)
+
+	if err := query.Scan(&entities).Error; err != nil {
+		return nil, errors.NewDatabaseError("failed to get notifications announced after event", err)
+	}
+
+	notifications := make([]*models.Notification, 0, len(entities))
+	for _, entity := range entities {
+		notification, err := r.toModel(&entity)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationRepository) GetByDigestID(ctx context.Context, digestID string) ([]*models.Notification, error) {
+	var entities []NotificationEntity
+
+	err := r.db.WithContext(ctx).
+		Where("digest_id = ?", digestID).
+		Order("created_at DESC").
+		Find(&entities).Error
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to get digest notifications", err)
+	}
+
+	notifications := make([]*models.Notification, 0, len(entities))
+	for _, entity := range entities {
+		notification, err := r.toModel(&entity)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
+
 func (r *NotificationRepository) GetPendingNotifications(ctx context.Context, limit int) ([]*models.Notification, error) {
 	var entities []NotificationEntity
 	
@@ -139,6 +333,10 @@ func (r *NotificationRepository) UpdateStatus(ctx context.Context, id string, st
 		updates["sent_at"] = now
 	}
 
+	if status != string(constants.StatusFailed) {
+		updates["next_retry_at"] = nil
+	}
+
 	if errorMsg != "" {
 		updates["error_message"] = errorMsg
 	}
@@ -150,22 +348,321 @@ func (r *NotificationRepository) UpdateStatus(ctx context.Context, id string, st
 	return nil
 }
 
+func (r *NotificationRepository) ClaimScheduled(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.Notification, error) {
+	var entities []NotificationEntity
+
+	query := r.db.WithContext(ctx).Raw(`
+		UPDATE notifications SET status = ?, claimed_until = ?
+		WHERE id IN (
+			SELECT id FROM notifications
+			WHERE (status IN (?, ?) AND send_at <= ?)
+				OR (status = ? AND claimed_until <= ?)
+			ORDER BY COALESCE(send_at, claimed_until) ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		string(constants.StatusPending), now.Add(lease),
+		string(constants.StatusScheduled), string(constants.StatusDeferred), now,
+		string(constants.StatusPending), now,
+		limit,
+	)
+
+	if err := query.Scan(&entities).Error; err != nil {
+		return nil, errors.NewDatabaseError("failed to claim scheduled notifications", err)
+	}
+
+	notifications := make([]*models.Notification, 0, len(entities))
+	for _, entity := range entities {
+		notification, err := r.toModel(&entity)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationRepository) ClaimPending(ctx context.Context, id string, now time.Time, lease time.Duration) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&NotificationEntity{}).
+		Where("id = ? AND status = ? AND (claimed_until IS NULL OR claimed_until <= ?)", id, string(constants.StatusPending), now).
+		Update("claimed_until", now.Add(lease))
+	if result.Error != nil {
+		return false, errors.NewDatabaseError("failed to claim pending notification", result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *NotificationRepository) CancelScheduled(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).
+		Model(&NotificationEntity{}).
+		Where("id = ? AND status IN ?", id, []string{string(constants.StatusScheduled), string(constants.StatusDeferred)}).
+		Update("status", string(constants.StatusCancelled))
+
+	if result.Error != nil {
+		return errors.NewDatabaseError("failed to cancel scheduled notification", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		// Distinguish a missing notification from one that is no longer scheduled
+		if _, err := r.GetByID(ctx, id); err != nil {
+			return err
+		}
+		return errors.NewAppError(errors.ErrCodeConflict, "notification is not scheduled", nil)
+	}
+
+	return nil
+}
+
+func (r *NotificationRepository) Defer(ctx context.Context, id string, until time.Time) error {
+	err := r.db.WithContext(ctx).Model(&NotificationEntity{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":        string(constants.StatusDeferred),
+			"send_at":       until,
+			"next_retry_at": nil,
+		}).Error
+	if err != nil {
+		return errors.NewDatabaseError("failed to defer notification", err)
+	}
+
+	return nil
+}
+
+func (r *NotificationRepository) ReleaseDeferred(ctx context.Context, userID string) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&NotificationEntity{}).
+		Where("user_id = ? AND status = ?", userID, string(constants.StatusDeferred)).
+		Update("send_at", time.Now())
+	if result.Error != nil {
+		return 0, errors.NewDatabaseError("failed to release deferred notifications", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *NotificationRepository) ClaimRetryable(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.Notification, error) {
+	var entities []NotificationEntity
+
+	// The row stays failed with its next attempt pushed past the lease, so
+	// it is retried again should the worker die before recording the outcome
+	query := r.db.WithContext(ctx).Raw(`
+		UPDATE notifications SET retry_count = retry_count + 1, next_retry_at = ?
+		WHERE id IN (
+			SELECT id FROM notifications
+			WHERE status = ? AND next_retry_at <= ?
+			ORDER BY next_retry_at ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		now.Add(lease),
+		string(constants.StatusFailed), now,
+		limit,
+	)
+
+	if err := query.Scan(&entities).Error; err != nil {
+		return nil, errors.NewDatabaseError("failed to claim retryable notifications", err)
+	}
+
+	notifications := make([]*models.Notification, 0, len(entities))
+	for _, entity := range entities {
+		notification, err := r.toModel(&entity)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationRepository) ScheduleRetry(ctx context.Context, id string, nextRetryAt time.Time, errorMsg string) error {
+	updates := map[string]interface{}{
+		"status":        string(constants.StatusFailed),
+		"next_retry_at": nextRetryAt,
+		"error_message": errorMsg,
+	}
+
+	if err := r.db.WithContext(ctx).Model(&NotificationEntity{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return errors.NewDatabaseError("failed to schedule notification retry", err)
+	}
+
+	return nil
+}
+
+func (r *NotificationRepository) UpdateDeliveryResults(ctx context.Context, id string, channels []models.ChannelDelivery, deliveries []models.DeviceDelivery) error {
+	channelsJSON, err := json.Marshal(channels)
+	if err != nil {
+		return errors.NewDatabaseError("failed to marshal notification channels", err)
+	}
+
+	updates := map[string]interface{}{
+		"channels": string(channelsJSON),
+	}
+
+	if deliveries != nil {
+		deliveriesJSON, err := json.Marshal(deliveries)
+		if err != nil {
+			return errors.NewDatabaseError("failed to marshal notification deliveries", err)
+		}
+		updates["deliveries"] = string(deliveriesJSON)
+	}
+
+	if err := r.db.WithContext(ctx).Model(&NotificationEntity{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return errors.NewDatabaseError("failed to update notification delivery results", err)
+	}
+
+	return nil
+}
+
+// inboxStatuses are the statuses of notifications the user can see in the
+// inbox; scheduled, deferred, cancelled, suppressed and superseded ones have
+// not reached it
+var inboxStatuses = []string{
+	string(constants.StatusPending),
+	string(constants.StatusSent),
+	string(constants.StatusFailed),
+	string(constants.StatusPermanentlyFailed),
+	string(constants.StatusDigested),
+}
+
+func (r *NotificationRepository) MarkRead(ctx context.Context, id string) (*models.Notification, error) {
+	var entities []NotificationEntity
+
+	now := time.Now()
+	result := r.db.WithContext(ctx).Model(&entities).
+		Clauses(clause.Returning{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"read_at": gorm.Expr("COALESCE(read_at, ?)", now),
+			"seen_at": gorm.Expr("COALESCE(seen_at, ?)", now),
+		})
+	if result.Error != nil {
+		return nil, errors.NewDatabaseError("failed to mark notification as read", result.Error)
+	}
+
+	if len(entities) == 0 {
+		return nil, errors.NewAppError(errors.ErrCodeNotFound, "notification not found", nil)
+	}
+
+	return r.toModel(&entities[0])
+}
+
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID string, filter models.NotificationFilter) (int64, error) {
+	now := time.Now()
+	result := r.inbox(ctx, userID, filter).
+		Where("read_at IS NULL").
+		Updates(map[string]interface{}{
+			"read_at": now,
+			"seen_at": gorm.Expr("COALESCE(seen_at, ?)", now),
+		})
+	if result.Error != nil {
+		return 0, errors.NewDatabaseError("failed to mark notifications as read", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *NotificationRepository) MarkAllSeen(ctx context.Context, userID string, filter models.NotificationFilter) (int64, error) {
+	result := r.inbox(ctx, userID, filter).
+		Where("seen_at IS NULL").
+		Update("seen_at", time.Now())
+	if result.Error != nil {
+		return 0, errors.NewDatabaseError("failed to mark notifications as seen", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID string) (*models.UnreadCount, error) {
+	var count models.UnreadCount
+
+	err := r.inbox(ctx, userID, models.NotificationFilter{}).
+		Where("read_at IS NULL").
+		Select("COUNT(*) AS unread, COUNT(*) FILTER (WHERE seen_at IS NULL) AS unseen").
+		Scan(&count).Error
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to count unread notifications", err)
+	}
+
+	return &count, nil
+}
+
+// inbox scopes a query to the notifications a user can see in the inbox
+func (r *NotificationRepository) inbox(ctx context.Context, userID string, filter models.NotificationFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&NotificationEntity{}).
+		Where("user_id = ? AND status IN ?", userID, inboxStatuses)
+
+	return applyFilter(query, filter)
+}
+
+func applyFilter(query *gorm.DB, filter models.NotificationFilter) *gorm.DB {
+	if filter.NotificationType != "" {
+		query = query.Where("notification_type = ?", string(filter.NotificationType))
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+		query = query.Where("status IN ?", statuses)
+	}
+	if filter.ProjectID != "" {
+		query = query.Where("project_id = ?", filter.ProjectID)
+	}
+	if filter.TaskID != "" {
+		query = query.Where("task_id = ?", filter.TaskID)
+	}
+	if filter.Read != nil {
+		if *filter.Read {
+			query = query.Where("read_at IS NOT NULL")
+		} else {
+			query = query.Where("read_at IS NULL")
+		}
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+
+	return query
+}
+
 func (r *NotificationRepository) toEntity(notification *models.Notification) *NotificationEntity {
 	entity := &NotificationEntity{
 		ID:               notification.ID,
 		NotificationType: string(notification.NotificationType),
 		UserID:           notification.UserID,
 		FCMToken:         notification.FCMToken,
+		RecipientEmail:   notification.RecipientEmail,
 		Title:            notification.Title,
 		Body:             notification.Body,
 		Status:           string(notification.Status),
 		ErrorMessage:     notification.ErrorMessage,
 		CreatedAt:        notification.CreatedAt,
 		SentAt:           notification.SentAt,
+		SendAt:           notification.SendAt,
+		ClaimedUntil:     notification.ClaimedUntil,
 		RetryCount:       notification.RetryCount,
+		NextRetryAt:      notification.NextRetryAt,
+		ReadAt:           notification.ReadAt,
+		SeenAt:           notification.SeenAt,
 		TaskID:           notification.TaskID,
 		ProjectID:        notification.ProjectID,
 		Priority:         notification.Priority,
+		CoalesceKey:      notification.CoalesceKey,
+		IdempotencyKey:   notification.IdempotencyKey,
+		TargetTopic:      notification.TargetTopic,
+		TargetCondition:  notification.TargetCondition,
+		RecipientCount:   notification.RecipientCount,
+	}
+
+	if notification.DigestID != "" {
+		entity.DigestID = &notification.DigestID
 	}
 
 	if notification.Data != nil {
@@ -173,6 +670,16 @@ func (r *NotificationRepository) toEntity(notification *models.Notification) *No
 		entity.Data = string(dataJSON)
 	}
 
+	if len(notification.Channels) > 0 {
+		channelsJSON, _ := json.Marshal(notification.Channels)
+		entity.Channels = string(channelsJSON)
+	}
+
+	if len(notification.Deliveries) > 0 {
+		deliveriesJSON, _ := json.Marshal(notification.Deliveries)
+		entity.Deliveries = string(deliveriesJSON)
+	}
+
 	return entity
 }
 
@@ -182,16 +689,31 @@ func (r *NotificationRepository) toModel(entity *NotificationEntity) (*models.No
 		NotificationType: models.NotificationType(entity.NotificationType),
 		UserID:           entity.UserID,
 		FCMToken:         entity.FCMToken,
+		RecipientEmail:   entity.RecipientEmail,
 		Title:            entity.Title,
 		Body:             entity.Body,
 		Status:           models.NotificationStatus(entity.Status),
 		ErrorMessage:     entity.ErrorMessage,
 		CreatedAt:        entity.CreatedAt,
 		SentAt:           entity.SentAt,
+		SendAt:           entity.SendAt,
+		ClaimedUntil:     entity.ClaimedUntil,
 		RetryCount:       entity.RetryCount,
+		NextRetryAt:      entity.NextRetryAt,
+		ReadAt:           entity.ReadAt,
+		SeenAt:           entity.SeenAt,
 		TaskID:           entity.TaskID,
 		ProjectID:        entity.ProjectID,
 		Priority:         entity.Priority,
+		CoalesceKey:      entity.CoalesceKey,
+		IdempotencyKey:   entity.IdempotencyKey,
+		TargetTopic:      entity.TargetTopic,
+		TargetCondition:  entity.TargetCondition,
+		RecipientCount:   entity.RecipientCount,
+	}
+
+	if entity.DigestID != nil {
+		notification.DigestID = *entity.DigestID
 	}
 
 	if entity.Data != "" {
@@ -202,9 +724,26 @@ func (r *NotificationRepository) toModel(entity *NotificationEntity) (*models.No
 		notification.Data = data
 	}
 
+	if entity.Channels != "" {
+		if err := json.Unmarshal([]byte(entity.Channels), &notification.Channels); err != nil {
+			return nil, errors.NewDatabaseError("failed to unmarshal notification channels", err)
+		}
+	}
+
+	if entity.Deliveries != "" {
+		if err := json.Unmarshal([]byte(entity.Deliveries), &notification.Deliveries); err != nil {
+			return nil, errors.NewDatabaseError("failed to unmarshal notification deliveries", err)
+		}
+	}
+
 	return notification, nil
 }
 
+// DB exposes the underlying connection so other repositories can share it
+func (r *NotificationRepository) DB() *gorm.DB {
+	return r.db
+}
+
 func (r *NotificationRepository) Close() error {
 	sqlDB, err := r.db.DB()
 	if err != nil {
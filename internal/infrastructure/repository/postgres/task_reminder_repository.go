@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/pkg/constants"
+	"gorm.io/gorm"
+)
+
+type TaskReminderEntity struct {
+	ID           string     `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	TaskID       string     `gorm:"column:task_id;type:varchar(100);not null;index"`
+	UserID       string     `gorm:"column:user_id;type:varchar(100);not null"`
+	FCMToken     string     `gorm:"column:fcm_token;type:text;not null"`
+	Email        string     `gorm:"column:email;type:varchar(255)"`
+	TaskTitle    string     `gorm:"column:task_title;type:varchar(255);not null"`
+	ProjectID    string     `gorm:"column:project_id;type:varchar(100)"`
+	Priority     int        `gorm:"column:priority"`
+	DueDate      time.Time  `gorm:"column:due_date;not null"`
+	ReminderType string     `gorm:"column:reminder_type;type:varchar(20);not null"`
+	RemindAt     time.Time  `gorm:"column:remind_at;not null"`
+	Status       string     `gorm:"column:status;type:varchar(20);not null;default:pending"`
+	CreatedAt    time.Time  `gorm:"column:created_at;not null;default:now()"`
+	SentAt       *time.Time `gorm:"column:sent_at"`
+	ClaimedUntil *time.Time `gorm:"column:claimed_until"`
+}
+
+func (TaskReminderEntity) TableName() string {
+	return "task_reminders"
+}
+
+type TaskReminderRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskReminderRepository(db *gorm.DB) *TaskReminderRepository {
+	return &TaskReminderRepository{db: db}
+}
+
+func (r *TaskReminderRepository) ReplaceForTask(ctx context.Context, taskID string, reminders []*models.TaskReminder) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := cancelPendingReminders(tx, taskID); err != nil {
+			return err
+		}
+
+		if len(reminders) == 0 {
+			return nil
+		}
+
+		entities := make([]*TaskReminderEntity, len(reminders))
+		for i, reminder := range reminders {
+			entities[i] = r.toEntity(reminder)
+		}
+
+		if err := tx.Create(&entities).Error; err != nil {
+			return err
+		}
+
+		for i, entity := range entities {
+			reminders[i].ID = entity.ID
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.NewDatabaseError("failed to schedule task reminders", err)
+	}
+
+	return nil
+}
+
+func (r *TaskReminderRepository) CancelForTask(ctx context.Context, taskID string) error {
+	if err := cancelPendingReminders(r.db.WithContext(ctx), taskID); err != nil {
+		return errors.NewDatabaseError("failed to cancel task reminders", err)
+	}
+
+	return nil
+}
+
+func (r *TaskReminderRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.TaskReminder, error) {
+	var entities []TaskReminderEntity
+
+	query := r.db.WithContext(ctx).Raw(`
+		UPDATE task_reminders SET claimed_until = ?
+		WHERE id IN (
+			SELECT id FROM task_reminders
+			WHERE status = ? AND remind_at <= ?
+			AND (claimed_until IS NULL OR claimed_until <= ?)
+			ORDER BY remind_at ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		now.Add(lease),
+		string(constants.ReminderStatusPending), now,
+		now,
+		limit,
+	)
+
+	if err := query.Scan(&entities).Error; err != nil {
+		return nil, errors.NewDatabaseError("failed to claim due task reminders", err)
+	}
+
+	reminders := make([]*models.TaskReminder, 0, len(entities))
+	for i := range entities {
+		reminders = append(reminders, r.toModel(&entities[i]))
+	}
+
+	return reminders, nil
+}
+
+func (r *TaskReminderRepository) MarkSent(ctx context.Context, id string, sentAt time.Time) error {
+	err := r.db.WithContext(ctx).Model(&TaskReminderEntity{}).
+		Where("id = ? AND status = ?", id, string(constants.ReminderStatusPending)).
+		Updates(map[string]interface{}{
+			"status":        string(constants.ReminderStatusSent),
+			"sent_at":       sentAt,
+			"claimed_until": nil,
+		}).Error
+	if err != nil {
+		return errors.NewDatabaseError("failed to mark task reminder as sent", err)
+	}
+
+	return nil
+}
+
+func cancelPendingReminders(db *gorm.DB, taskID string) error {
+	return db.Model(&TaskReminderEntity{}).
+		Where("task_id = ? AND status = ?", taskID, string(constants.ReminderStatusPending)).
+		Update("status", string(constants.ReminderStatusCancelled)).Error
+}
+
+func (r *TaskReminderRepository) toEntity(reminder *models.TaskReminder) *TaskReminderEntity {
+	return &TaskReminderEntity{
+		ID:           reminder.ID,
+		TaskID:       reminder.TaskID,
+		UserID:       reminder.UserID,
+		FCMToken:     reminder.FCMToken,
+		Email:        reminder.Email,
+		TaskTitle:    reminder.TaskTitle,
+		ProjectID:    reminder.ProjectID,
+		Priority:     reminder.Priority,
+		DueDate:      reminder.DueDate,
+		ReminderType: string(reminder.ReminderType),
+		RemindAt:     reminder.RemindAt,
+		Status:       string(reminder.Status),
+		CreatedAt:    reminder.CreatedAt,
+		SentAt:       reminder.SentAt,
+		ClaimedUntil: reminder.ClaimedUntil,
+	}
+}
+
+func (r *TaskReminderRepository) toModel(entity *TaskReminderEntity) *models.TaskReminder {
+	return &models.TaskReminder{
+		ID:           entity.ID,
+		TaskID:       entity.TaskID,
+		UserID:       entity.UserID,
+		FCMToken:     entity.FCMToken,
+		Email:        entity.Email,
+		TaskTitle:    entity.TaskTitle,
+		ProjectID:    entity.ProjectID,
+		Priority:     entity.Priority,
+		DueDate:      entity.DueDate,
+		ReminderType: models.ReminderType(entity.ReminderType),
+		RemindAt:     entity.RemindAt,
+		Status:       models.ReminderStatus(entity.Status),
+		CreatedAt:    entity.CreatedAt,
+		SentAt:       entity.SentAt,
+		ClaimedUntil: entity.ClaimedUntil,
+	}
+}
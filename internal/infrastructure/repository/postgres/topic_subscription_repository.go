@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TopicSubscriptionEntity struct {
+	ID        string    `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID    string    `gorm:"column:user_id;type:varchar(100);not null;uniqueIndex:uq_topic_subscriptions_user_topic"`
+	Topic     string    `gorm:"column:topic;type:varchar(255);not null;uniqueIndex:uq_topic_subscriptions_user_topic;index"`
+	CreatedAt time.Time `gorm:"column:created_at;not null;default:now()"`
+}
+
+func (TopicSubscriptionEntity) TableName() string {
+	return "topic_subscriptions"
+}
+
+type TopicSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewTopicSubscriptionRepository(db *gorm.DB) *TopicSubscriptionRepository {
+	return &TopicSubscriptionRepository{db: db}
+}
+
+func (r *TopicSubscriptionRepository) Add(ctx context.Context, userID string, topic string) error {
+	entity := &TopicSubscriptionEntity{
+		UserID:    userID,
+		Topic:     topic,
+		CreatedAt: time.Now(),
+	}
+
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(entity).Error; err != nil {
+		return errors.NewDatabaseError("failed to add topic subscription", err)
+	}
+
+	return nil
+}
+
+func (r *TopicSubscriptionRepository) Remove(ctx context.Context, userID string, topic string) error {
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND topic = ?", userID, topic).
+		Delete(&TopicSubscriptionEntity{}).Error; err != nil {
+		return errors.NewDatabaseError("failed to remove topic subscription", err)
+	}
+
+	return nil
+}
+
+func (r *TopicSubscriptionRepository) GetTopicsByUserID(ctx context.Context, userID string) ([]string, error) {
+	var topics []string
+
+	if err := r.db.WithContext(ctx).Model(&TopicSubscriptionEntity{}).
+		Where("user_id = ?", userID).
+		Order("topic").
+		Pluck("topic", &topics).Error; err != nil {
+		return nil, errors.NewDatabaseError("failed to get topic subscriptions", err)
+	}
+
+	return topics, nil
+}
+
+func (r *TopicSubscriptionRepository) CountUsers(ctx context.Context, topics []string) (int, error) {
+	if len(topics) == 0 {
+		return 0, nil
+	}
+
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&TopicSubscriptionEntity{}).
+		Where("topic IN ?", topics).
+		Distinct("user_id").
+		Count(&count).Error; err != nil {
+		return 0, errors.NewDatabaseError("failed to count topic subscribers", err)
+	}
+
+	return int(count), nil
+}
@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebPushSubscriptionEntity struct {
+	ID          string    `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID      string    `gorm:"column:user_id;type:varchar(100);not null"`
+	Endpoint    string    `gorm:"column:endpoint;type:text;not null;uniqueIndex:uq_web_push_subscriptions_endpoint"`
+	P256dh      string    `gorm:"column:p256dh;type:varchar(255);not null"`
+	Auth        string    `gorm:"column:auth;type:varchar(255);not null"`
+	UserAgent   string    `gorm:"column:user_agent;type:varchar(255)"`
+	CreatedAt   time.Time `gorm:"column:created_at;not null;default:now()"`
+	LastUpdated time.Time `gorm:"column:last_updated;not null;default:now()"`
+}
+
+func (WebPushSubscriptionEntity) TableName() string {
+	return "web_push_subscriptions"
+}
+
+type WebPushSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewWebPushSubscriptionRepository(db *gorm.DB) *WebPushSubscriptionRepository {
+	return &WebPushSubscriptionRepository{db: db}
+}
+
+func (r *WebPushSubscriptionRepository) Upsert(ctx context.Context, subscription *models.WebPushSubscription) error {
+	now := time.Now()
+	entity := r.toEntity(subscription)
+	entity.CreatedAt = now
+	entity.LastUpdated = now
+
+	// An endpoint belongs to one browser; re-subscribing moves it to the new user
+	err := r.db.WithContext(ctx).Clauses(
+		clause.OnConflict{
+			Columns:   []clause.Column{{Name: "endpoint"}},
+			DoUpdates: clause.AssignmentColumns([]string{"user_id", "p256dh", "auth", "user_agent", "last_updated"}),
+		},
+		clause.Returning{},
+	).Create(entity).Error
+	if err != nil {
+		return errors.NewDatabaseError("failed to store web push subscription", err)
+	}
+
+	*subscription = *r.toModel(entity)
+	return nil
+}
+
+func (r *WebPushSubscriptionRepository) GetByUserID(ctx context.Context, userID string) ([]*models.WebPushSubscription, error) {
+	var entities []WebPushSubscriptionEntity
+
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("last_updated DESC").Find(&entities).Error; err != nil {
+		return nil, errors.NewDatabaseError("failed to get web push subscriptions", err)
+	}
+
+	subscriptions := make([]*models.WebPushSubscription, 0, len(entities))
+	for i := range entities {
+		subscriptions = append(subscriptions, r.toModel(&entities[i]))
+	}
+
+	return subscriptions, nil
+}
+
+func (r *WebPushSubscriptionRepository) Delete(ctx context.Context, userID string, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&WebPushSubscriptionEntity{})
+	if result.Error != nil {
+		return errors.NewDatabaseError("failed to delete web push subscription", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewAppError(errors.ErrCodeNotFound, "web push subscription not found", nil)
+	}
+
+	return nil
+}
+
+func (r *WebPushSubscriptionRepository) DeleteByEndpoint(ctx context.Context, endpoint string) error {
+	if err := r.db.WithContext(ctx).Where("endpoint = ?", endpoint).Delete(&WebPushSubscriptionEntity{}).Error; err != nil {
+		return errors.NewDatabaseError("failed to delete web push subscription", err)
+	}
+	return nil
+}
+
+func (r *WebPushSubscriptionRepository) toEntity(subscription *models.WebPushSubscription) *WebPushSubscriptionEntity {
+	return &WebPushSubscriptionEntity{
+		ID:          subscription.ID,
+		UserID:      subscription.UserID,
+		Endpoint:    subscription.Endpoint,
+		P256dh:      subscription.P256dh,
+		Auth:        subscription.Auth,
+		UserAgent:   subscription.UserAgent,
+		CreatedAt:   subscription.CreatedAt,
+		LastUpdated: subscription.LastUpdated,
+	}
+}
+
+func (r *WebPushSubscriptionRepository) toModel(entity *WebPushSubscriptionEntity) *models.WebPushSubscription {
+	return &models.WebPushSubscription{
+		ID:          entity.ID,
+		UserID:      entity.UserID,
+		Endpoint:    entity.Endpoint,
+		P256dh:      entity.P256dh,
+		Auth:        entity.Auth,
+		UserAgent:   entity.UserAgent,
+		CreatedAt:   entity.CreatedAt,
+		LastUpdated: entity.LastUpdated,
+	}
+}
@@ -0,0 +1,189 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"github.com/corechain/notification-service/pkg/constants"
+	"gorm.io/gorm"
+)
+
+type WebhookDeliveryEntity struct {
+	ID             string     `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	SubscriptionID string     `gorm:"column:subscription_id;type:uuid;not null"`
+	NotificationID string     `gorm:"column:notification_id;type:uuid;not null"`
+	Payload        string     `gorm:"column:payload;type:jsonb;not null"`
+	Status         string     `gorm:"column:status;type:varchar(20);not null;default:pending"`
+	AttemptCount   int        `gorm:"column:attempt_count;not null;default:0"`
+	NextAttemptAt  time.Time  `gorm:"column:next_attempt_at;not null;default:now()"`
+	LastStatusCode int        `gorm:"column:last_status_code"`
+	LastError      string     `gorm:"column:last_error;type:text"`
+	CreatedAt      time.Time  `gorm:"column:created_at;not null;default:now()"`
+	DeliveredAt    *time.Time `gorm:"column:delivered_at"`
+}
+
+func (WebhookDeliveryEntity) TableName() string {
+	return "webhook_deliveries"
+}
+
+type WebhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookDeliveryRepository(db *gorm.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{db: db}
+}
+
+func (r *WebhookDeliveryRepository) CreateBatch(ctx context.Context, deliveries []*models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	entities := make([]*WebhookDeliveryEntity, len(deliveries))
+	for i, delivery := range deliveries {
+		entities[i] = r.toEntity(delivery)
+	}
+
+	if err := r.db.WithContext(ctx).Create(&entities).Error; err != nil {
+		return errors.NewDatabaseError("failed to create webhook deliveries", err)
+	}
+
+	for i, entity := range entities {
+		deliveries[i].ID = entity.ID
+	}
+
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.WebhookDelivery, error) {
+	var entities []WebhookDeliveryEntity
+
+	query := r.db.WithContext(ctx).Raw(`
+		UPDATE webhook_deliveries SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT d.id FROM webhook_deliveries d
+			JOIN webhook_subscriptions s ON s.id = d.subscription_id
+			WHERE d.status = ? AND d.next_attempt_at <= ? AND s.is_active
+			ORDER BY d.next_attempt_at ASC
+			LIMIT ?
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		RETURNING *`,
+		now.Add(lease),
+		string(constants.WebhookDeliveryPending), now,
+		limit,
+	)
+
+	if err := query.Scan(&entities).Error; err != nil {
+		return nil, errors.NewDatabaseError("failed to claim webhook deliveries", err)
+	}
+
+	deliveries := make([]*models.WebhookDelivery, 0, len(entities))
+	for i := range entities {
+		deliveries = append(deliveries, r.toModel(&entities[i]))
+	}
+
+	return deliveries, nil
+}
+
+func (r *WebhookDeliveryRepository) MarkDelivered(ctx context.Context, id string, statusCode int) error {
+	return r.recordAttempt(ctx, id, map[string]interface{}{
+		"status":           string(constants.WebhookDeliveryDelivered),
+		"last_status_code": statusCode,
+		"last_error":       "",
+		"delivered_at":     time.Now(),
+	})
+}
+
+func (r *WebhookDeliveryRepository) ScheduleRetry(ctx context.Context, id string, nextAttemptAt time.Time, statusCode int, errorMsg string) error {
+	return r.recordAttempt(ctx, id, map[string]interface{}{
+		"next_attempt_at":  nextAttemptAt,
+		"last_status_code": statusCode,
+		"last_error":       errorMsg,
+	})
+}
+
+func (r *WebhookDeliveryRepository) MarkFailed(ctx context.Context, id string, statusCode int, errorMsg string) error {
+	return r.recordAttempt(ctx, id, map[string]interface{}{
+		"status":           string(constants.WebhookDeliveryFailed),
+		"last_status_code": statusCode,
+		"last_error":       errorMsg,
+	})
+}
+
+func (r *WebhookDeliveryRepository) FailPending(ctx context.Context, subscriptionID string, reason string) error {
+	if err := r.db.WithContext(ctx).Model(&WebhookDeliveryEntity{}).
+		Where("subscription_id = ? AND status = ?", subscriptionID, string(constants.WebhookDeliveryPending)).
+		Updates(map[string]interface{}{
+			"status":     string(constants.WebhookDeliveryFailed),
+			"last_error": reason,
+		}).Error; err != nil {
+		return errors.NewDatabaseError("failed to fail pending webhook deliveries", err)
+	}
+
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) GetBySubscriptionID(ctx context.Context, subscriptionID string, limit int) ([]*models.WebhookDelivery, error) {
+	var entities []WebhookDeliveryEntity
+
+	if err := r.db.WithContext(ctx).
+		Where("subscription_id = ?", subscriptionID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&entities).Error; err != nil {
+		return nil, errors.NewDatabaseError("failed to get webhook deliveries", err)
+	}
+
+	deliveries := make([]*models.WebhookDelivery, 0, len(entities))
+	for i := range entities {
+		deliveries = append(deliveries, r.toModel(&entities[i]))
+	}
+
+	return deliveries, nil
+}
+
+// recordAttempt stores the outcome of one delivery attempt
+func (r *WebhookDeliveryRepository) recordAttempt(ctx context.Context, id string, updates map[string]interface{}) error {
+	updates["attempt_count"] = gorm.Expr("attempt_count + 1")
+
+	if err := r.db.WithContext(ctx).Model(&WebhookDeliveryEntity{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return errors.NewDatabaseError("failed to update webhook delivery", err)
+	}
+
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) toEntity(delivery *models.WebhookDelivery) *WebhookDeliveryEntity {
+	return &WebhookDeliveryEntity{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		NotificationID: delivery.NotificationID,
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		AttemptCount:   delivery.AttemptCount,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+}
+
+func (r *WebhookDeliveryRepository) toModel(entity *WebhookDeliveryEntity) *models.WebhookDelivery {
+	return &models.WebhookDelivery{
+		ID:             entity.ID,
+		SubscriptionID: entity.SubscriptionID,
+		NotificationID: entity.NotificationID,
+		Payload:        entity.Payload,
+		Status:         models.WebhookDeliveryStatus(entity.Status),
+		AttemptCount:   entity.AttemptCount,
+		NextAttemptAt:  entity.NextAttemptAt,
+		LastStatusCode: entity.LastStatusCode,
+		LastError:      entity.LastError,
+		CreatedAt:      entity.CreatedAt,
+		DeliveredAt:    entity.DeliveredAt,
+	}
+}
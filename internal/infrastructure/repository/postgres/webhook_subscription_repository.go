@@ -0,0 +1,190 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookSubscriptionEntity struct {
+	ID                  string     `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	URL                 string     `gorm:"column:url;type:text;not null"`
+	Secret              string     `gorm:"column:secret;type:varchar(255);not null"`
+	Description         string     `gorm:"column:description;type:varchar(255)"`
+	ProjectID           string     `gorm:"column:project_id;type:varchar(100)"`
+	NotificationType    string     `gorm:"column:notification_type;type:varchar(50)"`
+	IsActive            bool       `gorm:"column:is_active;not null;default:true"`
+	ConsecutiveFailures int        `gorm:"column:consecutive_failures;not null;default:0"`
+	DisabledAt          *time.Time `gorm:"column:disabled_at"`
+	DisabledReason      string     `gorm:"column:disabled_reason;type:text"`
+	CreatedAt           time.Time  `gorm:"column:created_at;not null;default:now()"`
+	UpdatedAt           time.Time  `gorm:"column:updated_at;not null;default:now()"`
+}
+
+func (WebhookSubscriptionEntity) TableName() string {
+	return "webhook_subscriptions"
+}
+
+type WebhookSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookSubscriptionRepository(db *gorm.DB) *WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{db: db}
+}
+
+func (r *WebhookSubscriptionRepository) Create(ctx context.Context, subscription *models.WebhookSubscription) error {
+	entity := r.toEntity(subscription)
+
+	if err := r.db.WithContext(ctx).Create(entity).Error; err != nil {
+		return errors.NewDatabaseError("failed to create webhook subscription", err)
+	}
+
+	subscription.ID = entity.ID
+	return nil
+}
+
+func (r *WebhookSubscriptionRepository) Update(ctx context.Context, subscription *models.WebhookSubscription) error {
+	entity := r.toEntity(subscription)
+
+	result := r.db.WithContext(ctx).Model(&WebhookSubscriptionEntity{}).
+		Where("id = ?", subscription.ID).
+		Select("*").Omit("id", "created_at").
+		Updates(entity)
+
+	if result.Error != nil {
+		return errors.NewDatabaseError("failed to update webhook subscription", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewAppError(errors.ErrCodeNotFound, "webhook subscription not found", nil)
+	}
+
+	return nil
+}
+
+func (r *WebhookSubscriptionRepository) GetByID(ctx context.Context, id string) (*models.WebhookSubscription, error) {
+	var entity WebhookSubscriptionEntity
+
+	if err := r.db.WithContext(ctx).First(&entity, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewAppError(errors.ErrCodeNotFound, "webhook subscription not found", err)
+		}
+		return nil, errors.NewDatabaseError("failed to get webhook subscription", err)
+	}
+
+	return r.toModel(&entity), nil
+}
+
+func (r *WebhookSubscriptionRepository) List(ctx context.Context, projectID string) ([]*models.WebhookSubscription, error) {
+	query := r.db.WithContext(ctx)
+	if projectID != "" {
+		query = query.Where("project_id = ?", projectID)
+	}
+
+	return r.find(ctx, query)
+}
+
+func (r *WebhookSubscriptionRepository) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&WebhookSubscriptionEntity{})
+
+	if result.Error != nil {
+		return errors.NewDatabaseError("failed to delete webhook subscription", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewAppError(errors.ErrCodeNotFound, "webhook subscription not found", nil)
+	}
+
+	return nil
+}
+
+func (r *WebhookSubscriptionRepository) GetMatching(ctx context.Context, projectID string, notificationType string) ([]*models.WebhookSubscription, error) {
+	return r.find(ctx, r.db.WithContext(ctx).
+		Where("is_active").
+		Where("COALESCE(project_id, '') IN ('', ?)", projectID).
+		Where("COALESCE(notification_type, '') IN ('', ?)", notificationType))
+}
+
+func (r *WebhookSubscriptionRepository) RecordSuccess(ctx context.Context, id string) error {
+	if err := r.db.WithContext(ctx).Model(&WebhookSubscriptionEntity{}).
+		Where("id = ? AND consecutive_failures > 0", id).
+		Update("consecutive_failures", 0).Error; err != nil {
+		return errors.NewDatabaseError("failed to reset webhook failure count", err)
+	}
+
+	return nil
+}
+
+func (r *WebhookSubscriptionRepository) RecordFailure(ctx context.Context, id string, disableAfter int, reason string) (bool, error) {
+	var entities []WebhookSubscriptionEntity
+
+	result := r.db.WithContext(ctx).Model(&entities).
+		Clauses(clause.Returning{}).
+		Where("id = ? AND is_active", id).
+		Updates(map[string]interface{}{
+			"consecutive_failures": gorm.Expr("consecutive_failures + 1"),
+			"is_active":            gorm.Expr("consecutive_failures + 1 < ?", disableAfter),
+			"disabled_at":          gorm.Expr("CASE WHEN consecutive_failures + 1 >= ? THEN NOW() END", disableAfter),
+			"disabled_reason":      gorm.Expr("CASE WHEN consecutive_failures + 1 >= ? THEN ? END", disableAfter, reason),
+		})
+
+	if result.Error != nil {
+		return false, errors.NewDatabaseError("failed to record webhook failure", result.Error)
+	}
+
+	return len(entities) > 0 && !entities[0].IsActive, nil
+}
+
+func (r *WebhookSubscriptionRepository) find(ctx context.Context, query *gorm.DB) ([]*models.WebhookSubscription, error) {
+	var entities []WebhookSubscriptionEntity
+
+	if err := query.Order("created_at ASC").Find(&entities).Error; err != nil {
+		return nil, errors.NewDatabaseError("failed to get webhook subscriptions", err)
+	}
+
+	subscriptions := make([]*models.WebhookSubscription, 0, len(entities))
+	for i := range entities {
+		subscriptions = append(subscriptions, r.toModel(&entities[i]))
+	}
+
+	return subscriptions, nil
+}
+
+func (r *WebhookSubscriptionRepository) toEntity(subscription *models.WebhookSubscription) *WebhookSubscriptionEntity {
+	return &WebhookSubscriptionEntity{
+		ID:                  subscription.ID,
+		URL:                 subscription.URL,
+		Secret:              subscription.Secret,
+		Description:         subscription.Description,
+		ProjectID:           subscription.ProjectID,
+		NotificationType:    subscription.NotificationType,
+		IsActive:            subscription.IsActive,
+		ConsecutiveFailures: subscription.ConsecutiveFailures,
+		DisabledAt:          subscription.DisabledAt,
+		DisabledReason:      subscription.DisabledReason,
+		CreatedAt:           subscription.CreatedAt,
+		UpdatedAt:           subscription.UpdatedAt,
+	}
+}
+
+func (r *WebhookSubscriptionRepository) toModel(entity *WebhookSubscriptionEntity) *models.WebhookSubscription {
+	return &models.WebhookSubscription{
+		ID:                  entity.ID,
+		URL:                 entity.URL,
+		Secret:              entity.Secret,
+		Description:         entity.Description,
+		ProjectID:           entity.ProjectID,
+		NotificationType:    entity.NotificationType,
+		IsActive:            entity.IsActive,
+		ConsecutiveFailures: entity.ConsecutiveFailures,
+		DisabledAt:          entity.DisabledAt,
+		DisabledReason:      entity.DisabledReason,
+		CreatedAt:           entity.CreatedAt,
+		UpdatedAt:           entity.UpdatedAt,
+	}
+}
@@ -0,0 +1,117 @@
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/corechain/notification-service/internal/domain/models"
+	"github.com/corechain/notification-service/internal/utils/errors"
+)
+
+// Urgency values of RFC 8030; push services may hold back low urgency
+// messages to save battery
+const (
+	UrgencyVeryLow = "very-low"
+	UrgencyLow     = "low"
+	UrgencyNormal  = "normal"
+	UrgencyHigh    = "high"
+)
+
+// maxResponseBody bounds how much of an error response is kept for the log
+const maxResponseBody = 512
+
+type Config struct {
+	VAPIDPublicKey  string
+	VAPIDPrivateKey string
+	// Subject is the mailto: or https: contact push services reach out to
+	Subject string
+	// TTL is how long the push service keeps a message for an offline browser
+	TTL     time.Duration
+	Timeout time.Duration
+}
+
+type Client struct {
+	keys       *vapidKeys
+	subject    string
+	ttl        time.Duration
+	httpClient *http.Client
+}
+
+func NewClient(config Config) (*Client, error) {
+	keys, err := parseVAPIDKeys(config.VAPIDPublicKey, config.VAPIDPrivateKey)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrCodeConfiguration, "invalid VAPID keys", err)
+	}
+
+	return &Client{
+		keys:       keys,
+		subject:    config.Subject,
+		ttl:        config.TTL,
+		httpClient: &http.Client{Timeout: config.Timeout},
+	}, nil
+}
+
+func (c *Client) PublicKey() string {
+	return c.keys.publicKey
+}
+
+func (c *Client) Send(ctx context.Context, subscription *models.WebPushSubscription, payload []byte, urgency string) (string, error) {
+	userAgentKey, authSecret, err := decodeKeys(subscription.P256dh, subscription.Auth)
+	if err != nil {
+		return "", errors.NewWebPushErrorWithCode(errors.ErrCodeWebPushGone, "subscription keys are invalid", err)
+	}
+
+	body, err := encrypt(userAgentKey, authSecret, payload)
+	if err != nil {
+		return "", errors.NewWebPushErrorWithCode(errors.ErrCodeWebPush, "failed to encrypt web push payload", err)
+	}
+
+	authorization, err := c.keys.authorization(subscription.Endpoint, c.subject, time.Now())
+	if err != nil {
+		return "", errors.NewWebPushErrorWithCode(errors.ErrCodeWebPush, "failed to authorize web push request", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", errors.NewWebPushErrorWithCode(errors.ErrCodeWebPushGone, "invalid push endpoint", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", strconv.Itoa(int(c.ttl.Seconds())))
+	req.Header.Set("Authorization", authorization)
+	if urgency != "" {
+		req.Header.Set("Urgency", urgency)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", errors.NewWebPushErrorWithCode(errors.ErrCodeWebPushUnavailable, "web push request failed", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return resp.Header.Get("Location"), nil
+	}
+
+	responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	message := "push service answered " + strconv.Itoa(resp.StatusCode) + ": " + string(bytes.TrimSpace(responseBody))
+
+	return "", errors.NewWebPushErrorWithCode(statusCode(resp.StatusCode), message, nil)
+}
+
+// statusCode maps a push service response to an error code. 404 and 410 mean
+// the browser dropped the subscription; rate limits and server errors pass.
+func statusCode(status int) string {
+	switch {
+	case status == http.StatusNotFound || status == http.StatusGone:
+		return errors.ErrCodeWebPushGone
+	case status == http.StatusTooManyRequests || status >= 500:
+		return errors.ErrCodeWebPushUnavailable
+	default:
+		return errors.ErrCodeWebPush
+	}
+}
@@ -0,0 +1,142 @@
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// recordSize is the aes128gcm record size; every payload fits one record
+	recordSize = 4096
+	// MaxPayloadSize is the largest plaintext that keeps the encrypted body
+	// within the 4096 bytes every push service accepts: the header takes 86
+	// bytes, the padding delimiter 1 and the GCM tag 16
+	MaxPayloadSize = 4096 - 86 - 1 - 16
+
+	authSecretSize = 16
+)
+
+// ValidateKeys checks the keys of a browser subscription: p256dh must be an
+// uncompressed P-256 point and auth a 16 byte secret, both base64url encoded
+func ValidateKeys(p256dh string, auth string) error {
+	_, _, err := decodeKeys(p256dh, auth)
+	return err
+}
+
+func decodeKeys(p256dh string, auth string) (*ecdh.PublicKey, []byte, error) {
+	rawPublic, err := decodeBase64(p256dh)
+	if err != nil {
+		return nil, nil, fmt.Errorf("p256dh is not base64url: %w", err)
+	}
+	publicKey, err := ecdh.P256().NewPublicKey(rawPublic)
+	if err != nil {
+		return nil, nil, fmt.Errorf("p256dh is not a P-256 public key: %w", err)
+	}
+
+	authSecret, err := decodeBase64(auth)
+	if err != nil {
+		return nil, nil, fmt.Errorf("auth is not base64url: %w", err)
+	}
+	if len(authSecret) != authSecretSize {
+		return nil, nil, fmt.Errorf("auth must be %d bytes, got %d", authSecretSize, len(authSecret))
+	}
+
+	return publicKey, authSecret, nil
+}
+
+// encrypt encrypts a payload for a subscription with the aes128gcm content
+// coding of RFC 8188, keyed as described in RFC 8291
+func encrypt(userAgentKey *ecdh.PublicKey, authSecret []byte, payload []byte) ([]byte, error) {
+	if len(payload) > MaxPayloadSize {
+		return nil, fmt.Errorf("payload of %d bytes exceeds %d bytes", len(payload), MaxPayloadSize)
+	}
+
+	serverKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	return encryptWith(serverKey, salt, userAgentKey, authSecret, payload)
+}
+
+// encryptWith encrypts a payload with the given ephemeral server key and
+// salt, which must never be reused for another message
+func encryptWith(serverKey *ecdh.PrivateKey, salt []byte, userAgentKey *ecdh.PublicKey, authSecret []byte, payload []byte) ([]byte, error) {
+	sharedSecret, err := serverKey.ECDH(userAgentKey)
+	if err != nil {
+		return nil, err
+	}
+
+	userAgentPublic := userAgentKey.Bytes()
+	serverPublic := serverKey.PublicKey().Bytes()
+
+	keyInfo := make([]byte, 0, 14+len(userAgentPublic)+len(serverPublic))
+	keyInfo = append(keyInfo, "WebPush: info\x00"...)
+	keyInfo = append(keyInfo, userAgentPublic...)
+	keyInfo = append(keyInfo, serverPublic...)
+
+	ikm, err := deriveKey(sharedSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	contentKey, err := deriveKey(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := deriveKey(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// Header: salt, record size, key ID length and the server public key as key ID
+	body := make([]byte, 0, 16+4+1+len(serverPublic)+len(payload)+1+gcm.Overhead())
+	body = append(body, salt...)
+	body = binary.BigEndian.AppendUint32(body, recordSize)
+	body = append(body, byte(len(serverPublic)))
+	body = append(body, serverPublic...)
+
+	// A single, and therefore last, record ends with the 0x02 delimiter
+	record := make([]byte, 0, len(payload)+1)
+	record = append(record, payload...)
+	record = append(record, 0x02)
+
+	return gcm.Seal(body, nonce, record, nil), nil
+}
+
+func deriveKey(secret []byte, salt []byte, info []byte, size int) ([]byte, error) {
+	key := make([]byte, size)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, info), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// decodeBase64 accepts base64url with or without padding, as browsers and
+// key generators differ
+func decodeBase64(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+}
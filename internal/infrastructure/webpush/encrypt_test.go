@@ -0,0 +1,161 @@
+package webpush
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"testing"
+)
+
+// Test vector of RFC 8291, Appendix A
+const (
+	vectorPlaintext        = "When I grow up, I want to be a watermelon"
+	vectorServerPrivateKey = "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"
+	vectorUserAgentPrivate = "q1dXpw3UpT5VOmu_cf_v6ih07Aems3njxI-JWgLcM94"
+	vectorUserAgentPublic  = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	vectorAuthSecret       = "BTBZMqHH6r4Tts7J_aSIgg"
+	vectorSalt             = "DGv6ra1nlYgDCS1FRnbzlw"
+	vectorBody             = "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN"
+)
+
+func TestEncryptMatchesRFC8291Vector(t *testing.T) {
+	serverKey, err := ecdh.P256().NewPrivateKey(mustDecode(t, vectorServerPrivateKey))
+	if err != nil {
+		t.Fatalf("server key: %v", err)
+	}
+	userAgentKey, authSecret, err := decodeKeys(vectorUserAgentPublic, vectorAuthSecret)
+	if err != nil {
+		t.Fatalf("subscription keys: %v", err)
+	}
+
+	body, err := encryptWith(serverKey, mustDecode(t, vectorSalt), userAgentKey, authSecret, []byte(vectorPlaintext))
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+
+	if got := base64.RawURLEncoding.EncodeToString(body); got != vectorBody {
+		t.Errorf("encrypted body\n got %s\nwant %s", got, vectorBody)
+	}
+}
+
+func TestEncryptRoundTrip(t *testing.T) {
+	userAgentKey, authSecret, err := decodeKeys(vectorUserAgentPublic, vectorAuthSecret)
+	if err != nil {
+		t.Fatalf("subscription keys: %v", err)
+	}
+	userAgentPrivate, err := ecdh.P256().NewPrivateKey(mustDecode(t, vectorUserAgentPrivate))
+	if err != nil {
+		t.Fatalf("user agent key: %v", err)
+	}
+
+	payload := []byte(`{"title":"Task assigned","body":"Review the release notes"}`)
+	body, err := encrypt(userAgentKey, authSecret, payload)
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+
+	decrypted, err := decrypt(userAgentPrivate, authSecret, body)
+	if err != nil {
+		t.Fatalf("decrypt: %v", err)
+	}
+	if !bytes.Equal(decrypted, payload) {
+		t.Errorf("decrypted %q, want %q", decrypted, payload)
+	}
+
+	// Every message gets its own salt and server key
+	again, err := encrypt(userAgentKey, authSecret, payload)
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	if bytes.Equal(again, body) {
+		t.Error("two encryptions of the same payload are identical")
+	}
+}
+
+func TestEncryptRejectsOversizedPayload(t *testing.T) {
+	userAgentKey, authSecret, err := decodeKeys(vectorUserAgentPublic, vectorAuthSecret)
+	if err != nil {
+		t.Fatalf("subscription keys: %v", err)
+	}
+
+	if _, err := encrypt(userAgentKey, authSecret, make([]byte, MaxPayloadSize+1)); err == nil {
+		t.Error("expected an error for a payload over MaxPayloadSize")
+	}
+
+	body, err := encrypt(userAgentKey, authSecret, make([]byte, MaxPayloadSize))
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	if len(body) > recordSize {
+		t.Errorf("body of %d bytes exceeds %d bytes", len(body), recordSize)
+	}
+}
+
+// decrypt reverses encrypt the way a browser does, with the subscription's
+// private key
+func decrypt(userAgentKey *ecdh.PrivateKey, authSecret []byte, body []byte) ([]byte, error) {
+	salt := body[:16]
+	idLength := int(body[20])
+	serverPublic := body[21 : 21+idLength]
+	ciphertext := body[21+idLength:]
+	if rs := binary.BigEndian.Uint32(body[16:20]); rs != recordSize {
+		return nil, fmt.Errorf("record size %d, want %d", rs, recordSize)
+	}
+
+	serverKey, err := ecdh.P256().NewPublicKey(serverPublic)
+	if err != nil {
+		return nil, err
+	}
+	sharedSecret, err := userAgentKey.ECDH(serverKey)
+	if err != nil {
+		return nil, err
+	}
+
+	keyInfo := append([]byte("WebPush: info\x00"), userAgentKey.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, serverPublic...)
+	ikm, err := deriveKey(sharedSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	contentKey, err := deriveKey(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := deriveKey(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	record, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Strip the zero padding and the last record delimiter
+	record = bytes.TrimRight(record, "\x00")
+	if len(record) == 0 || record[len(record)-1] != 0x02 {
+		return nil, fmt.Errorf("record has no last-record delimiter")
+	}
+	return record[:len(record)-1], nil
+}
+
+func mustDecode(t *testing.T, value string) []byte {
+	t.Helper()
+	decoded, err := decodeBase64(value)
+	if err != nil {
+		t.Fatalf("decode %s: %v", value, err)
+	}
+	return decoded
+}
@@ -0,0 +1,62 @@
+package webpush
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// ValidateEndpoint checks that a subscription endpoint is an https URL on a
+// public host, so registering a subscription can't make the service post to
+// itself or its internal network. Host names are resolved and every address
+// must be public. allowInsecure skips these checks for local development
+// against a push service stub.
+func ValidateEndpoint(ctx context.Context, endpoint string, allowInsecure bool) error {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("endpoint is not a URL: %w", err)
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("endpoint has no host")
+	}
+	if allowInsecure {
+		return nil
+	}
+
+	if parsed.Scheme != "https" {
+		return fmt.Errorf("endpoint must use https, got %q", parsed.Scheme)
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("endpoint host %s is not public", host)
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		if !isPublicIP(ip) {
+			return fmt.Errorf("endpoint address %s is not public", ip)
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("endpoint host %s does not resolve: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if !isPublicIP(addr.IP) {
+			return fmt.Errorf("endpoint host %s resolves to %s, which is not public", host, addr.IP)
+		}
+	}
+
+	return nil
+}
+
+// isPublicIP reports whether ip is a unicast address outside the private
+// ranges; loopback, link-local, multicast and unspecified addresses are not
+// global unicast
+func isPublicIP(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate()
+}
@@ -0,0 +1,75 @@
+package webpush
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/url"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// vapidTokenLifetime is how long a VAPID token is valid; push services
+// reject tokens that expire more than 24 hours ahead
+const vapidTokenLifetime = 12 * time.Hour
+
+// vapidKeys is the application server key pair of RFC 8292
+type vapidKeys struct {
+	privateKey *ecdsa.PrivateKey
+	publicKey  string
+}
+
+// parseVAPIDKeys reads a base64url encoded P-256 key pair, in the format
+// printed by common VAPID key generators, and checks that the halves match
+func parseVAPIDKeys(publicKey string, privateKey string) (*vapidKeys, error) {
+	rawPrivate, err := decodeBase64(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("VAPID private key is not base64url: %w", err)
+	}
+	key, err := ecdh.P256().NewPrivateKey(rawPrivate)
+	if err != nil {
+		return nil, fmt.Errorf("VAPID private key is not a P-256 key: %w", err)
+	}
+
+	rawPublic := key.PublicKey().Bytes()
+	if configured, err := decodeBase64(publicKey); err != nil || string(configured) != string(rawPublic) {
+		return nil, fmt.Errorf("VAPID public key does not belong to the private key")
+	}
+
+	// Uncompressed point: 0x04 || X || Y
+	return &vapidKeys{
+		privateKey: &ecdsa.PrivateKey{
+			PublicKey: ecdsa.PublicKey{
+				Curve: elliptic.P256(),
+				X:     new(big.Int).SetBytes(rawPublic[1:33]),
+				Y:     new(big.Int).SetBytes(rawPublic[33:65]),
+			},
+			D: new(big.Int).SetBytes(rawPrivate),
+		},
+		publicKey: base64.RawURLEncoding.EncodeToString(rawPublic),
+	}, nil
+}
+
+// authorization returns the VAPID Authorization header for a push endpoint.
+// The token audience is the origin of the push service.
+func (k *vapidKeys) authorization(endpoint string, subject string, now time.Time) (string, error) {
+	target, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid push endpoint: %w", err)
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": target.Scheme + "://" + target.Host,
+		"exp": now.Add(vapidTokenLifetime).Unix(),
+		"sub": subject,
+	}).SignedString(k.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign VAPID token: %w", err)
+	}
+
+	return fmt.Sprintf("vapid t=%s, k=%s", token, k.publicKey), nil
+}
@@ -0,0 +1,90 @@
+package webpush
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// The application server key pair of RFC 8291, Appendix A
+const (
+	testVAPIDPrivateKey = "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"
+	testVAPIDPublicKey  = "BP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A8"
+)
+
+func TestParseVAPIDKeysRejectsMismatchedPair(t *testing.T) {
+	if _, err := parseVAPIDKeys(vectorUserAgentPublic, testVAPIDPrivateKey); err == nil {
+		t.Error("expected an error for a public key of another pair")
+	}
+}
+
+func TestAuthorization(t *testing.T) {
+	keys, err := parseVAPIDKeys(testVAPIDPublicKey, testVAPIDPrivateKey)
+	if err != nil {
+		t.Fatalf("parse keys: %v", err)
+	}
+
+	now := time.Now()
+	header, err := keys.authorization("https://push.example.net/push/JzLQ3raZJfFBR0aqvOMsLrt54w4rJUsV", "mailto:ops@example.com", now)
+	if err != nil {
+		t.Fatalf("authorization: %v", err)
+	}
+
+	token, publicKey, ok := parseVAPIDHeader(header)
+	if !ok {
+		t.Fatalf("malformed header %q", header)
+	}
+	if publicKey != testVAPIDPublicKey {
+		t.Errorf("k = %s, want %s", publicKey, testVAPIDPublicKey)
+	}
+
+	// Push services verify the token with the key from the header
+	rawPublic := mustDecode(t, publicKey)
+	verifyKey := &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(rawPublic[1:33]),
+		Y:     new(big.Int).SetBytes(rawPublic[33:65]),
+	}
+	claims := jwt.MapClaims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		return verifyKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodES256.Alg()}))
+	if err != nil || !parsed.Valid {
+		t.Fatalf("token does not verify: %v", err)
+	}
+
+	if claims["aud"] != "https://push.example.net" {
+		t.Errorf("aud = %v, want the push service origin", claims["aud"])
+	}
+	if claims["sub"] != "mailto:ops@example.com" {
+		t.Errorf("sub = %v", claims["sub"])
+	}
+	if exp, _ := claims["exp"].(float64); int64(exp) != now.Add(vapidTokenLifetime).Unix() {
+		t.Errorf("exp = %v, want %d", claims["exp"], now.Add(vapidTokenLifetime).Unix())
+	}
+}
+
+// parseVAPIDHeader splits "vapid t=<token>, k=<key>" into its parameters
+func parseVAPIDHeader(header string) (string, string, bool) {
+	params, ok := strings.CutPrefix(header, "vapid ")
+	if !ok {
+		return "", "", false
+	}
+
+	var token, key string
+	for _, param := range strings.Split(params, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		switch name {
+		case "t":
+			token = value
+		case "k":
+			key = value
+		}
+	}
+	return token, key, token != "" && key != ""
+}
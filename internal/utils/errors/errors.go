@@ -1,11 +1,17 @@
 package errors
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 type AppError struct {
 	Code    string
 	Message string
 	Err     error
+	// Transient marks failures that may succeed when retried later, such as
+	// FCM 5xx or quota errors
+	Transient bool
 }
 
 func (e *AppError) Error() string {
@@ -20,13 +26,26 @@ func (e *AppError) Unwrap() error {
 }
 
 const (
-	ErrCodeDatabase        = "DATABASE_ERROR"
-	ErrCodeKafka           = "KAFKA_ERROR"
-	ErrCodeFCM             = "FCM_ERROR"
-	ErrCodeInvalidPayload  = "INVALID_PAYLOAD"
-	ErrCodeConfiguration   = "CONFIGURATION_ERROR"
-	ErrCodeNotFound        = "NOT_FOUND"
-	ErrCodeInternal        = "INTERNAL_ERROR"
+	ErrCodeDatabase           = "DATABASE_ERROR"
+	ErrCodeKafka              = "KAFKA_ERROR"
+	ErrCodeFCM                = "FCM_ERROR"
+	ErrCodeFCMUnregistered    = "FCM_UNREGISTERED"
+	ErrCodeFCMInvalidToken    = "FCM_INVALID_TOKEN"
+	ErrCodeFCMQuota           = "FCM_QUOTA_EXCEEDED"
+	ErrCodeFCMUnavailable     = "FCM_UNAVAILABLE"
+	ErrCodeFCMAuth            = "FCM_AUTH_ERROR"
+	ErrCodeEmail              = "EMAIL_ERROR"
+	ErrCodeEmailRejected      = "EMAIL_REJECTED"
+	ErrCodeEmailUnavailable   = "EMAIL_UNAVAILABLE"
+	ErrCodeWebPush            = "WEBPUSH_ERROR"
+	ErrCodeWebPushGone        = "WEBPUSH_SUBSCRIPTION_GONE"
+	ErrCodeWebPushUnavailable = "WEBPUSH_UNAVAILABLE"
+	ErrCodeInvalidPayload     = "INVALID_PAYLOAD"
+	ErrCodeConfiguration      = "CONFIGURATION_ERROR"
+	ErrCodeNotFound           = "NOT_FOUND"
+	ErrCodeConflict           = "CONFLICT"
+	ErrCodeDuplicate          = "DUPLICATE"
+	ErrCodeInternal           = "INTERNAL_ERROR"
 )
 
 func NewAppError(code, message string, err error) *AppError {
@@ -49,6 +68,70 @@ func NewFCMError(message string, err error) *AppError {
 	return NewAppError(ErrCodeFCM, message, err)
 }
 
+// NewFCMErrorWithCode creates one of the typed FCM errors. Quota and
+// availability errors are transient.
+func NewFCMErrorWithCode(code, message string, err error) *AppError {
+	appErr := NewAppError(code, message, err)
+	appErr.Transient = code == ErrCodeFCMQuota || code == ErrCodeFCMUnavailable
+	return appErr
+}
+
+// NewEmailErrorWithCode creates one of the typed email errors. Only an
+// unavailable mail server is transient; rejected recipients bounce for good.
+func NewEmailErrorWithCode(code, message string, err error) *AppError {
+	appErr := NewAppError(code, message, err)
+	appErr.Transient = code == ErrCodeEmailUnavailable
+	return appErr
+}
+
+// NewWebPushErrorWithCode creates one of the typed Web Push errors. Rate
+// limits and push service outages are transient.
+func NewWebPushErrorWithCode(code, message string, err error) *AppError {
+	appErr := NewAppError(code, message, err)
+	appErr.Transient = code == ErrCodeWebPushUnavailable
+	return appErr
+}
+
 func NewInvalidPayloadError(message string, err error) *AppError {
 	return NewAppError(ErrCodeInvalidPayload, message, err)
 }
+
+// CodeOf returns the AppError code carried by err, or ErrCodeInternal when err
+// is not (and does not wrap) an AppError
+func CodeOf(err error) string {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return ErrCodeInternal
+}
+
+// IsDeadToken reports whether err means the device token or Web Push
+// subscription will never work again, because it was unregistered, expired
+// or rejected as invalid
+func IsDeadToken(err error) bool {
+	switch CodeOf(err) {
+	case ErrCodeFCMUnregistered, ErrCodeFCMInvalidToken, ErrCodeWebPushGone:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsTransient reports whether err is a temporary failure that should be retried
+// later. Database and Kafka errors are always transient, FCM errors only when
+// the provider reported an outage or quota problem. Everything else, notably
+// invalid payloads, is permanent.
+func IsTransient(err error) bool {
+	var appErr *AppError
+	if !errors.As(err, &appErr) {
+		return false
+	}
+
+	switch appErr.Code {
+	case ErrCodeDatabase, ErrCodeKafka:
+		return true
+	default:
+		return appErr.Transient
+	}
+}
@@ -0,0 +1,18 @@
+package idempotency
+
+import "context"
+
+type contextKey struct{}
+
+// WithKey returns a context carrying the idempotency key of the event being
+// handled, so notifications created for it can be recognised when the event
+// is delivered again
+func WithKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, contextKey{}, key)
+}
+
+// Key returns the idempotency key carried by ctx, or "" when there is none
+func Key(ctx context.Context) string {
+	key, _ := ctx.Value(contextKey{}).(string)
+	return key
+}
@@ -7,9 +7,26 @@ const (
 	
 	NotificationTypeTaskUpdated NotificationType = "task_updated"
 	
+	NotificationTypeTaskDeleted NotificationType = "task_deleted"
+	
+	NotificationTypeTaskReassigned NotificationType = "task_reassigned"
+	
+	NotificationTypeTaskStatusChanged NotificationType = "task_status_changed"
+	
+	NotificationTypeTaskCompleted NotificationType = "task_completed"
+	
+	NotificationTypeTaskDueSoon NotificationType = "task_due_soon"
+	
+	NotificationTypeTaskOverdue NotificationType = "task_overdue"
+	
 	NotificationTypeNewMessage NotificationType = "new_message"
 	
 	NotificationTypeIncomingCall NotificationType = "incoming_call"
+	
+	NotificationTypeBroadcast NotificationType = "broadcast"
+	
+	// NotificationTypeDigest summarises the notifications collected in a digest
+	NotificationTypeDigest NotificationType = "digest"
 )
 
 type NotificationStatus string
@@ -20,6 +37,27 @@ const (
 	StatusSent NotificationStatus = "sent"
 	
 	StatusFailed NotificationStatus = "failed"
+	
+	StatusScheduled NotificationStatus = "scheduled"
+	
+	StatusCancelled NotificationStatus = "cancelled"
+	
+	StatusPermanentlyFailed NotificationStatus = "permanently_failed"
+	
+	// StatusSuppressed marks a notification the recipient's preferences held back
+	StatusSuppressed NotificationStatus = "suppressed"
+	
+	// StatusDeferred marks a notification held back until the recipient's
+	// quiet hours or do-not-disturb end
+	StatusDeferred NotificationStatus = "deferred"
+	
+	// StatusDigested marks a notification that is in the inbox but only
+	// announced through the summary of its digest
+	StatusDigested NotificationStatus = "digested"
+	
+	// StatusSuperseded marks a notification replaced by a newer update of the
+	// same task before it was sent
+	StatusSuperseded NotificationStatus = "superseded"
 )
 
 const (
@@ -33,3 +71,110 @@ const (
 	TaskStatusInProgress = 1
 	TaskStatusCompleted  = 2
 )
+
+type AttemptStatus string
+
+const (
+	AttemptStatusSucceeded AttemptStatus = "succeeded"
+
+	AttemptStatusFailed AttemptStatus = "failed"
+)
+
+// Delivery providers recorded in the attempt log
+const (
+	ProviderFCM     = "fcm"
+	ProviderSMTP    = "smtp"
+	ProviderWebPush = "webpush"
+)
+
+// Delivery channels a notification can be routed to
+const (
+	ChannelPush    = "push"
+	ChannelEmail   = "email"
+	ChannelWebPush = "webpush"
+)
+
+// ChannelStatus is the delivery state of a notification on one channel
+type ChannelStatus string
+
+const (
+	ChannelStatusSent              ChannelStatus = "sent"
+	ChannelStatusFailed            ChannelStatus = "failed"
+	ChannelStatusPermanentlyFailed ChannelStatus = "permanently_failed"
+	ChannelStatusSkipped           ChannelStatus = "skipped"
+)
+
+// WebhookDeliveryStatus is the state of one webhook delivery
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// EventTypeNotificationSent is the event webhook subscribers receive
+const EventTypeNotificationSent = "notification.sent"
+
+// EventTypeDeviceTokenInvalidated is emitted when FCM rejects a device token for good
+const EventTypeDeviceTokenInvalidated = "device.token.invalidated"
+
+// Device platforms accepted by the token registry
+const (
+	PlatformAndroid = "android"
+	PlatformIOS     = "ios"
+	PlatformWeb     = "web"
+)
+
+// DigestStatus is the state of a notification digest
+type DigestStatus string
+
+const (
+	DigestStatusOpen    DigestStatus = "open"
+	DigestStatusSending DigestStatus = "sending"
+	DigestStatusSent    DigestStatus = "sent"
+)
+
+type ReminderType string
+
+const (
+	ReminderTypeDue24h ReminderType = "due_24h"
+
+	ReminderTypeDue1h ReminderType = "due_1h"
+
+	ReminderTypeOverdue ReminderType = "overdue"
+)
+
+type ReminderStatus string
+
+const (
+	ReminderStatusPending ReminderStatus = "pending"
+
+	ReminderStatusSent ReminderStatus = "sent"
+
+	ReminderStatusCancelled ReminderStatus = "cancelled"
+)
+
+// Task event types published by the backend in the event_type field
+const (
+	EventTypeTaskCreated       = "task.created"
+	EventTypeTaskUpdated       = "task.updated"
+	EventTypeTaskDeleted       = "task.deleted"
+	EventTypeTaskReassigned    = "task.reassigned"
+	EventTypeTaskStatusChanged = "task.status_changed"
+	EventTypeTaskCompleted     = "task.completed"
+)
+
+// Membership event types published by the backend in the event_type field
+const (
+	EventTypeProjectMemberAdded   = "project.member_added"
+	EventTypeProjectMemberRemoved = "project.member_removed"
+	EventTypeCompanyMemberAdded   = "company.member_added"
+	EventTypeCompanyMemberRemoved = "company.member_removed"
+)
+
+// FCM topic prefixes; project_<ProjectID> reaches every member of a project
+const (
+	TopicPrefixProject = "project_"
+	TopicPrefixCompany = "company_"
+)